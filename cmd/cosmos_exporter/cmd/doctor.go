@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	cmthttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/forbole/cosmos-exporter/collector"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addConfigFlags(doctorCmd)
+	rootCmd.AddCommand(doctorCmd)
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check which queries and metrics are available on the configured node",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return loadConfig(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failed checks are reported in the tables, not by the usage
+		cmd.SilenceUsage = true
+
+		grpcConn, err := dialNodeDirect(*config)
+		if err != nil {
+			return err
+		}
+		defer grpcConn.Close()
+
+		rpcClient, err := newRPCClient(config.Node)
+		if err != nil {
+			return err
+		}
+
+		cosmosSDKCollector := collector.NewCosmosSDKCollector(cmd.Context(), grpcConn, rpcClient, collectorOptions(*config))
+		checks := cosmosSDKCollector.Diagnose(cmd.Context())
+
+		fmt.Println()
+		printChainInfo(cmd.Context(), rpcClient, cosmosSDKCollector)
+		fmt.Println()
+		printChecks(checks)
+		fmt.Println()
+		printEmptyMetrics(checks, config.Metrics.Naming)
+
+		var failed int
+		for _, check := range checks {
+			if check.Failed() {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+		}
+		return nil
+	},
+}
+
+func printChainInfo(ctx context.Context, rpcClient *cmthttp.HTTP, c *collector.CosmosSDKCollector) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Chain ID:\t%s\n", c.ChainID())
+	fmt.Fprintf(w, "SDK version:\t%s\n", c.SDKVersion())
+	fmt.Fprintf(w, "Node version:\t%s\n", nodeVersion(ctx, rpcClient))
+	fmt.Fprintf(w, "Bond denom:\t%s\n", c.BondDenom())
+	fmt.Fprintf(w, "Mint denom:\t%s\n", c.MintDenom())
+	w.Flush()
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BASE DENOM\tDISPLAY DENOM\tEXPONENT")
+	metadata := c.DenomMetadata()
+	denoms := make([]string, 0, len(metadata))
+	for denom := range metadata {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+	for _, denom := range denoms {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", metadata[denom].Base, metadata[denom].Display, metadata[denom].Exponent)
+	}
+	w.Flush()
+}
+
+func printChecks(checks []collector.Check) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tMODULE\tQUERY\tTARGET\tLATENCY\tERROR")
+	for _, check := range checks {
+		status, errMsg := "OK", ""
+		if check.Failed() {
+			status, errMsg = "FAIL", check.Err.Error()
+		}
+		latency := "-"
+		if check.Latency > 0 {
+			latency = check.Latency.Round(time.Millisecond).String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", status, check.Module, check.Query, check.Target, latency, errMsg)
+	}
+	w.Flush()
+}
+
+func printEmptyMetrics(checks []collector.Check, naming string) {
+	reasons := emptyMetrics(checks, naming)
+	if len(reasons) == 0 {
+		fmt.Println("All metrics should be populated")
+		return
+	}
+
+	metrics := make([]string, 0, len(reasons))
+	for metric := range reasons {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+
+	fmt.Println("Metrics that would be empty or incomplete:")
+	for _, metric := range metrics {
+		fmt.Printf("  %s\n", metric)
+		for _, reason := range reasons[metric] {
+			fmt.Printf("    - %s\n", reason)
+		}
+	}
+}
+
+// emptyMetrics returns the reasons by metric, named as selected by naming, of
+// the metrics depending on a failed check without a successful fallback
+func emptyMetrics(checks []collector.Check, naming string) map[string][]string {
+	succeeded := make(map[string]bool)
+	for _, check := range checks {
+		if !check.Failed() {
+			succeeded[check.Module+"/"+check.Query] = true
+		}
+	}
+
+	reasons := make(map[string][]string)
+	for _, check := range checks {
+		if !check.Failed() || (check.Fallback != "" && succeeded[check.Fallback]) {
+			continue
+		}
+		reason := fmt.Sprintf("%s/%s", check.Module, check.Query)
+		if check.Target != "" {
+			reason = fmt.Sprintf("%s (%s)", reason, check.Target)
+		}
+		reason = fmt.Sprintf("%s: %v", reason, check.Err)
+		for _, metric := range check.Metrics {
+			for _, name := range collector.MetricNames(metric, naming) {
+				reasons[name] = append(reasons[name], reason)
+			}
+		}
+	}
+	return reasons
+}
+
+func nodeVersion(ctx context.Context, client *cmthttp.HTTP) string {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	status, err := client.Status(ctx)
+	if err != nil {
+		return "unknown"
+	}
+	return status.NodeInfo.Version
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/forbole/cosmos-exporter/collector"
+)
+
+func TestEmptyMetrics(t *testing.T) {
+	failed := errors.New("unavailable")
+	params := func(err error) collector.Check {
+		return collector.Check{Module: "mint", Query: "Params", Metrics: []string{"tendermint_inflation_rate"}, Fallback: "mint/Inflation", Err: err}
+	}
+	inflation := func(err error) collector.Check {
+		return collector.Check{Module: "mint", Query: "Inflation", Metrics: []string{"tendermint_inflation_rate"}, Err: err}
+	}
+
+	tests := []struct {
+		name   string
+		checks []collector.Check
+		naming string
+		want   map[string][]string
+	}{
+		{"every check succeeded", []collector.Check{params(nil), inflation(nil)}, "", map[string][]string{}},
+		{"fallback succeeded", []collector.Check{params(failed), inflation(nil)}, "", map[string][]string{}},
+		{
+			name:   "fallback failed",
+			checks: []collector.Check{params(failed), inflation(failed)},
+			want: map[string][]string{
+				"tendermint_inflation_rate": {"mint/Params: unavailable", "mint/Inflation: unavailable"},
+			},
+		},
+		{
+			name:   "new naming",
+			checks: []collector.Check{inflation(failed)},
+			naming: collector.NamingNew,
+			want:   map[string][]string{"cosmos_mint_inflation_ratio": {"mint/Inflation: unavailable"}},
+		},
+		{
+			name:   "both namings",
+			checks: []collector.Check{inflation(failed)},
+			naming: collector.NamingBoth,
+			want: map[string][]string{
+				"tendermint_inflation_rate":   {"mint/Inflation: unavailable"},
+				"cosmos_mint_inflation_ratio": {"mint/Inflation: unavailable"},
+			},
+		},
+		{
+			name:   "metric without a legacy name",
+			checks: []collector.Check{{Module: "distribution", Query: "CommunityPool", Metrics: []string{"cosmos_distribution_community_pool_tokens"}, Err: failed}},
+			naming: collector.NamingLegacy,
+			want:   map[string][]string{"cosmos_distribution_community_pool_tokens": {"distribution/CommunityPool: unavailable"}},
+		},
+		{
+			name:   "target",
+			checks: []collector.Check{{Module: "bank", Query: "AllBalances", Target: "cosmos1a", Metrics: []string{"tendermint_available_balance"}, Err: failed}},
+			want:   map[string][]string{"tendermint_available_balance": {"bank/AllBalances (cosmos1a): unavailable"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := emptyMetrics(tt.checks, tt.naming); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("emptyMetrics() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
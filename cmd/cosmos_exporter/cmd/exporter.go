@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/forbole/cosmos-exporter/collector"
+	Config "github.com/forbole/cosmos-exporter/types/config"
+	"google.golang.org/grpc"
+)
+
+const collectInterval = 10 * time.Minute
+
+// exporter periodically collects chain metrics and applies config reloads
+// between two collections
+type exporter struct {
+	reloadCh chan Config.Config
+
+	// mu guards the fields replaced by a reload or read by the HTTP handlers
+	mu             sync.RWMutex
+	cfg            Config.Config
+	metricsFilter  *collector.MetricsFilter
+	grpcConn       *grpc.ClientConn
+	collector      *collector.CosmosSDKCollector
+	inFlight       *sync.WaitGroup
+	lastCollection time.Time
+	collectors     map[string]collectorStatus
+}
+
+func newExporter(ctx context.Context, cfg Config.Config) (*exporter, error) {
+	metricsFilter, err := collector.NewMetricsFilter(cfg.Metrics)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metrics config: %w", err)
+	}
+	grpcConn, err := dialNode(cfg)
+	if err != nil {
+		return nil, err
+	}
+	rpcClient, err := newRPCClient(cfg.Node)
+	if err != nil {
+		grpcConn.Close()
+		return nil, err
+	}
+
+	return &exporter{
+		cfg:           cfg,
+		metricsFilter: metricsFilter,
+		grpcConn:      grpcConn,
+		collector:     collector.NewCosmosSDKCollector(ctx, grpcConn, rpcClient, collectorOptions(cfg)),
+		inFlight:      &sync.WaitGroup{},
+		reloadCh:      make(chan Config.Config, 1),
+		collectors:    make(map[string]collectorStatus),
+	}, nil
+}
+
+// collectorOptions returns the collector settings of cfg
+func collectorOptions(cfg Config.Config) collector.Options {
+	return collector.Options{
+		ValidatorAddress:   cfg.ValidatorAddress,
+		DelegatorAddresses: cfg.DelegatorAddresses,
+		DenomMetadata:      cfg.DenomMetadata,
+		Concurrency:        cfg.Concurrency,
+		Labels:             cfg.Labels,
+		Balances:           cfg.Balances,
+		ValidatorSet:       cfg.ValidatorSet,
+		Validator:          cfg.Validator,
+		Delegators:         cfg.Delegators,
+	}
+}
+
+// Reload asks the exporter to apply cfg before the next collection, it
+// replaces a pending config which was not applied yet
+func (e *exporter) Reload(cfg Config.Config) {
+	for {
+		select {
+		case e.reloadCh <- cfg:
+			return
+		default:
+			select {
+			case <-e.reloadCh:
+			default:
+			}
+		}
+	}
+}
+
+// run collects metrics every collectInterval until ctx is cancelled
+func (e *exporter) run(ctx context.Context) {
+	for {
+		c, release := e.acquireCollector()
+		e.record(c.CollectChainMetrics(ctx))
+		release()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(collectInterval):
+		case cfg := <-e.reloadCh:
+			e.apply(ctx, cfg)
+		}
+	}
+}
+
+// acquireCollector returns the collector in use, which changes when a
+// reload dials the node again. Its connection stays open until release is
+// called.
+func (e *exporter) acquireCollector() (*collector.CosmosSDKCollector, func()) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	inFlight := e.inFlight
+	inFlight.Add(1)
+	return e.collector, inFlight.Done
+}
+
+func (e *exporter) close() {
+	e.mu.Lock()
+	grpcConn, inFlight := e.grpcConn, e.inFlight
+	e.mu.Unlock()
+
+	inFlight.Wait()
+	grpcConn.Close()
+}
+
+// apply reconciles the running collector with the new config, dialing the
+// node again when its connection settings changed and deleting the series of addresses
+// which are no longer monitored
+func (e *exporter) apply(ctx context.Context, cfg Config.Config) {
+	if cfg.Port != e.cfg.Port {
+		log.Printf("Port changed from %s to %s, restart the exporter to apply it", e.cfg.Port, cfg.Port)
+	}
+
+	e.mu.RLock()
+	oldCfg, oldCollector := e.cfg, e.collector
+	e.mu.RUnlock()
+	oldChainID := oldCollector.ChainID()
+
+	var metricsFilter *collector.MetricsFilter
+	if !reflect.DeepEqual(cfg.Metrics, oldCfg.Metrics) {
+		var err error
+		if metricsFilter, err = collector.NewMetricsFilter(cfg.Metrics); err != nil {
+			log.Printf("Invalid metrics config, keeping current config: %v", err)
+			return
+		}
+	}
+
+	newCollector := oldCollector
+	if cfg.DenomMetadata != oldCfg.DenomMetadata || !reflect.DeepEqual(connectionConfig(cfg), connectionConfig(oldCfg)) {
+		grpcConn, err := dialNode(cfg)
+		if err != nil {
+			log.Printf("Error dialing %s, keeping current config: %v", cfg.Node.GRPC, err)
+			return
+		}
+		rpcClient, err := newRPCClient(cfg.Node)
+		if err != nil {
+			grpcConn.Close()
+			log.Printf("Error creating RPC client for %s, keeping current config: %v", cfg.Node.RPC, err)
+			return
+		}
+
+		if oldEndpoint := HTTPProtocols.ReplaceAllString(oldCfg.Node.GRPC, ""); oldEndpoint != HTTPProtocols.ReplaceAllString(cfg.Node.GRPC, "") {
+			collector.CircuitBreakerStateGauge.DeleteLabelValues(oldEndpoint)
+		}
+		newCollector = collector.NewCosmosSDKCollector(ctx, grpcConn, rpcClient, collectorOptions(cfg))
+		if newCollector.ChainID() == oldChainID {
+			newCollector.KeepHistories(oldCollector)
+		}
+
+		e.mu.Lock()
+		oldConn, oldInFlight := e.grpcConn, e.inFlight
+		e.grpcConn, e.collector, e.inFlight = grpcConn, newCollector, &sync.WaitGroup{}
+		e.mu.Unlock()
+
+		// Probes may still be using the previous connection
+		go func() {
+			oldInFlight.Wait()
+			oldConn.Close()
+		}()
+	} else {
+		newCollector.SetAddresses(cfg.ValidatorAddress, cfg.DelegatorAddresses)
+		newCollector.SetLabels(cfg.Labels)
+		newCollector.SetBalances(cfg.Balances)
+		newCollector.SetValidatorSet(cfg.ValidatorSet)
+		newCollector.SetValidator(cfg.Validator)
+		newCollector.SetDelegators(cfg.Delegators)
+	}
+	if metricsFilter != nil {
+		e.metricsFilter.Replace(metricsFilter)
+	}
+	e.mu.Lock()
+	e.cfg = cfg
+	e.mu.Unlock()
+
+	if newCollector.ChainID() != oldChainID {
+		log.Printf("Chain changed from %s to %s, deleting its series", oldChainID, newCollector.ChainID())
+		collector.DeleteChainSeries(oldChainID)
+		return
+	}
+
+	if oldCfg.ValidatorAddress != "" && oldCfg.ValidatorAddress != cfg.ValidatorAddress {
+		log.Printf("Validator %s is no longer monitored, deleting its series", oldCfg.ValidatorAddress)
+		collector.DeleteValidatorSeries(oldChainID, oldCfg.ValidatorAddress)
+	}
+
+	monitored := make(map[string]bool, len(cfg.DelegatorAddresses))
+	for _, address := range cfg.DelegatorAddresses {
+		monitored[address] = true
+	}
+	for _, address := range oldCfg.DelegatorAddresses {
+		if !monitored[address] {
+			log.Printf("Address %s is no longer monitored, deleting its series", address)
+			collector.DeleteAddressSeries(oldChainID, address)
+		} else if cfg.Labels.Addresses[address] != oldCfg.Labels.Addresses[address] {
+			log.Printf("Labels of address %s changed, deleting its series", address)
+			collector.DeleteAddressSeries(oldChainID, address)
+		}
+	}
+
+	log.Printf("Config reloaded")
+}
+
+// connectionConfig returns the settings applied when dialing the node,
+// a change in any of them requires a new connection
+func connectionConfig(cfg Config.Config) []interface{} {
+	return []interface{}{cfg.Node, cfg.Concurrency, cfg.Retry, cfg.CircuitBreaker}
+}
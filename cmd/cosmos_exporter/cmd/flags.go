@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	Config "github.com/forbole/cosmos-exporter/types/config"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix is prepended to every config key to build its environment
+// variable, e.g. node.rpc is read from COSMOS_EXPORTER_NODE_RPC
+const EnvPrefix = "COSMOS_EXPORTER"
+
+// configFlags maps command line flags to config keys
+var configFlags = map[string]string{
+	"delegator-addresses":               "delegator_addresses",
+	"validator-address":                 "validator_address",
+	"port":                              "port",
+	"web-config-file":                   "web_config_file",
+	"denom-base":                        "denom_metadata.base_denom",
+	"denom-display":                     "denom_metadata.display_denom",
+	"denom-exponent":                    "denom_metadata.exponent",
+	"node-rpc":                          "node.rpc",
+	"node-grpc":                         "node.grpc",
+	"node-secure":                       "node.secure",
+	"node-ca-file":                      "node.tls.ca_file",
+	"node-cert-file":                    "node.tls.cert_file",
+	"node-key-file":                     "node.tls.key_file",
+	"node-server-name":                  "node.tls.server_name",
+	"node-insecure-skip-verify":         "node.tls.insecure_skip_verify",
+	"node-bearer-token-file":            "node.auth.bearer_token_file",
+	"node-username":                     "node.auth.username",
+	"node-password-file":                "node.auth.password_file",
+	"node-auth-insecure":                "node.auth.insecure",
+	"concurrency-collectors":            "concurrency.collectors",
+	"concurrency-max-requests":          "concurrency.max_requests",
+	"retry-max-attempts":                "retry.max_attempts",
+	"retry-initial-backoff":             "retry.initial_backoff",
+	"retry-max-backoff":                 "retry.max_backoff",
+	"retry-multiplier":                  "retry.multiplier",
+	"retry-codes":                       "retry.retryable_codes",
+	"circuit-breaker-failure-threshold": "circuit_breaker.failure_threshold",
+	"circuit-breaker-open-timeout":      "circuit_breaker.open_timeout",
+	"health-stale-intervals":            "health.stale_intervals",
+	"labels-validator-moniker":          "labels.validator_moniker",
+	"balances-window":                   "balances.window",
+	"metrics-naming":                    "metrics.naming",
+	"metrics-allow":                     "metrics.allow",
+	"metrics-deny":                      "metrics.deny",
+	"validator-set-enabled":             "validator_set.enabled",
+	"validator-set-top":                 "validator_set.top",
+	"validator-set-allowlist":           "validator_set.allowlist",
+	"validator-set-max-validators":      "validator_set.max_validators",
+	"validator-power-reduction":         "validator.power_reduction",
+	"validator-self-delegation-margin":  "validator.self_delegation_margin",
+	"validator-slash-window":            "validator.slash_window",
+	"delegators-top":                    "delegators.top",
+}
+
+// envOnlyKeys are config keys holding secrets, which can be overridden by
+// environment variables but not by flags to keep them out of the process list
+var envOnlyKeys = []string{
+	"node.auth.bearer_token",
+	"node.auth.password",
+}
+
+// jsonEnvKeys are the map config keys, which have no flag and are read from
+// environment variables holding a JSON object, e.g.
+// COSMOS_EXPORTER_NODE_AUTH_HEADERS='{"x-api-key":"..."}'
+var jsonEnvKeys = []string{
+	"labels.addresses",
+	"balances.thresholds",
+	"metrics.const_labels",
+	"node.auth.headers",
+	"node.auth.header_files",
+}
+
+// addConfigFlags registers a flag overriding each config field
+func addConfigFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringSlice("delegator-addresses", nil, "Delegator addresses to monitor, comma separated (overrides delegator_addresses)")
+	flags.String("validator-address", "", "Validator operator address to monitor (overrides validator_address)")
+	flags.String("port", "", "Address the metrics server listens on, e.g. :9092 (overrides port)")
+	flags.String("web-config-file", "", "Path to a web config file enabling TLS and basic auth on the metrics server (overrides web_config_file)")
+	flags.String("denom-base", "", "Base denom of the custom denom metadata (overrides denom_metadata.base_denom)")
+	flags.String("denom-display", "", "Display denom of the custom denom metadata (overrides denom_metadata.display_denom)")
+	flags.Uint32("denom-exponent", 0, "Exponent of the custom denom metadata (overrides denom_metadata.exponent)")
+	flags.String("node-rpc", "", "CometBFT RPC endpoint (overrides node.rpc)")
+	flags.String("node-grpc", "", "gRPC endpoint (overrides node.grpc)")
+	flags.Bool("node-secure", false, "Use TLS for the gRPC connection (overrides node.secure)")
+	flags.String("node-ca-file", "", "CA bundle verifying the node certificate (overrides node.tls.ca_file)")
+	flags.String("node-cert-file", "", "Client certificate for mutual TLS (overrides node.tls.cert_file)")
+	flags.String("node-key-file", "", "Client key for mutual TLS (overrides node.tls.key_file)")
+	flags.String("node-server-name", "", "Server name expected in the node certificate (overrides node.tls.server_name)")
+	flags.Bool("node-insecure-skip-verify", false, "Do not verify the node certificate (overrides node.tls.insecure_skip_verify)")
+	flags.String("node-bearer-token-file", "", "File holding the bearer token sent to the node (overrides node.auth.bearer_token_file)")
+	flags.String("node-username", "", "Basic auth username sent to the node (overrides node.auth.username)")
+	flags.String("node-password-file", "", "File holding the basic auth password sent to the node (overrides node.auth.password_file)")
+	flags.Bool("node-auth-insecure", false, "Allow sending the node credentials over plaintext connections (overrides node.auth.insecure)")
+	flags.Int("concurrency-collectors", 0, "Number of collectors running at the same time (overrides concurrency.collectors)")
+	flags.Int("concurrency-max-requests", 0, "Maximum number of gRPC queries in flight (overrides concurrency.max_requests)")
+	flags.Int("retry-max-attempts", 0, "Maximum attempts of a gRPC query, 1 disables retries (overrides retry.max_attempts)")
+	flags.Duration("retry-initial-backoff", 0, "Wait before the first retry (overrides retry.initial_backoff)")
+	flags.Duration("retry-max-backoff", 0, "Maximum wait between two retries (overrides retry.max_backoff)")
+	flags.Float64("retry-multiplier", 0, "Growth factor of the wait between two retries (overrides retry.multiplier)")
+	flags.StringSlice("retry-codes", nil, "gRPC status codes worth retrying, comma separated (overrides retry.retryable_codes)")
+	flags.Int("circuit-breaker-failure-threshold", 0, "Consecutive failures opening the circuit breaker, negative disables it (overrides circuit_breaker.failure_threshold)")
+	flags.Duration("circuit-breaker-open-timeout", 0, "Time the circuit breaker stays open before trying the node again (overrides circuit_breaker.open_timeout)")
+	flags.Int("health-stale-intervals", 0, "Collection intervals without a collection before /readyz fails (overrides health.stale_intervals)")
+	flags.Bool("labels-validator-moniker", false, "Add the validator moniker as moniker label to validator metrics (overrides labels.validator_moniker)")
+	flags.Duration("balances-window", 0, "Balance history used to estimate the spend rate of addresses, 1h when unset (overrides balances.window)")
+	flags.String("metrics-naming", "", "Names of the chain metrics: legacy (tendermint_*), new (cosmos_*) or both (overrides metrics.naming)")
+	flags.StringSlice("metrics-allow", nil, "Patterns of the only metric names exported, comma separated (overrides metrics.allow)")
+	flags.StringSlice("metrics-deny", nil, "Patterns of metric names never exported, comma separated (overrides metrics.deny)")
+	flags.Bool("validator-set-enabled", false, "Export the metrics of every validator of the set (overrides validator_set.enabled)")
+	flags.Int("validator-set-top", 0, "Only export the validators with the most tokens, within the allowlist when set, 0 exports all (overrides validator_set.top)")
+	flags.StringSlice("validator-set-allowlist", nil, "Only export these validator operator addresses, comma separated (overrides validator_set.allowlist)")
+	flags.Int("validator-set-max-validators", 0, "Maximum number of exported validators (overrides validator_set.max_validators)")
+	flags.Int64("validator-power-reduction", 0, "Tokens per unit of consensus power, the voting power mismatch is only checked when set (overrides validator.power_reduction)")
+	flags.Float64("validator-self-delegation-margin", 0, "Share above the minimum self-delegation from which the validator is flagged, 0.1 when unset (overrides validator.self_delegation_margin)")
+	flags.Int64("validator-slash-window", 0, "Number of latest blocks whose validator slashes are exported, 0 for the whole history (overrides validator.slash_window)")
+	flags.Int("delegators-top", 0, "Number of largest delegators of the validator exported, 10 when unset (overrides delegators.top)")
+}
+
+// bindConfigOverrides makes every config key readable from its environment
+// variable and from the flags of the running command. Precedence is
+// flag > environment variable > config file.
+func bindConfigOverrides(flags *pflag.FlagSet) error {
+	viper.SetEnvPrefix(EnvPrefix)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+
+	for _, key := range append(envOnlyKeys, jsonEnvKeys...) {
+		if err := viper.BindEnv(key); err != nil {
+			return err
+		}
+	}
+
+	for name, key := range configFlags {
+		// Keys missing from the config file are only unmarshalled when bound
+		if err := viper.BindEnv(key); err != nil {
+			return err
+		}
+
+		if flag := flags.Lookup(name); flag != nil {
+			if err := viper.BindPFlag(key, flag); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unmarshalConfig decodes the config with the default viper hooks, and
+// decodes the JSON objects set by environment variables for map keys
+func unmarshalConfig(cfg *Config.Config) error {
+	return viper.Unmarshal(cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		jsonToMapHook,
+	)))
+}
+
+// jsonToMapHook decodes a JSON object string into a map field
+func jsonToMapHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Map {
+		return data, nil
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(data.(string)), &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
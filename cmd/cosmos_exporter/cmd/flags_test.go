@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJSONToMapHook(t *testing.T) {
+	mapType := reflect.TypeOf(map[string]string{})
+	stringType := reflect.TypeOf("")
+
+	tests := []struct {
+		name    string
+		from    reflect.Type
+		to      reflect.Type
+		data    interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{"JSON object into a map", stringType, mapType, `{"x-api-key": "key"}`, map[string]interface{}{"x-api-key": "key"}, false},
+		{"string into a string", stringType, stringType, `{"x-api-key": "key"}`, `{"x-api-key": "key"}`, false},
+		{"map into a map", mapType, mapType, map[string]string{"a": "b"}, map[string]string{"a": "b"}, false},
+		{"not JSON into a map", stringType, mapType, "x-api-key=key", nil, true},
+		{"JSON array into a map", stringType, mapType, `["key"]`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsonToMapHook(tt.from, tt.to, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("jsonToMapHook() error = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("jsonToMapHook() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/forbole/cosmos-exporter/collector"
+)
+
+// pingTimeout bounds the node reachability check done by /readyz
+const pingTimeout = 2 * time.Second
+
+// collectorStatus is the outcome of the last run of a collector
+type collectorStatus struct {
+	LastRun         time.Time  `json:"last_run"`
+	DurationSeconds float64    `json:"duration_seconds"`
+	LastSuccess     *time.Time `json:"last_success,omitempty"`
+	LastError       string     `json:"last_error,omitempty"`
+}
+
+// exporterStatus is the body served by /status
+type exporterStatus struct {
+	ChainID        string                     `json:"chain_id"`
+	SDKVersion     string                     `json:"sdk_version"`
+	Ready          bool                       `json:"ready"`
+	LastCollection *time.Time                 `json:"last_collection,omitempty"`
+	Collectors     map[string]collectorStatus `json:"collectors"`
+}
+
+// record stores the results of a collection, the last collection only
+// advances when at least one collector succeeded in it
+func (e *exporter) record(results []collector.CollectorResult) {
+	if len(results) == 0 {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, result := range results {
+		status := e.collectors[result.Name]
+		status.LastRun = result.Start
+		status.DurationSeconds = result.Duration.Seconds()
+		status.LastError = ""
+		if result.Err != nil {
+			status.LastError = result.Err.Error()
+		} else {
+			lastSuccess := result.Start.Add(result.Duration)
+			status.LastSuccess = &lastSuccess
+			e.lastCollection = time.Now()
+		}
+		e.collectors[result.Name] = status
+	}
+}
+
+// checkReady returns why the exporter is not ready to be scraped, or nil
+func (e *exporter) checkReady(ctx context.Context) error {
+	e.mu.RLock()
+	err := e.checkCollection()
+	e.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	c, release := e.acquireCollector()
+	defer release()
+
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	if err := c.Ping(ctx); err != nil {
+		return fmt.Errorf("node is unreachable: %w", err)
+	}
+	return nil
+}
+
+// checkCollection returns an error when no collection with a successful
+// collector finished within the stale window, e.mu must be held
+func (e *exporter) checkCollection() error {
+	if e.lastCollection.IsZero() {
+		return fmt.Errorf("no successful collection yet")
+	}
+	maxAge := time.Duration(e.cfg.Health.WithDefaults().StaleIntervals) * collectInterval
+	if age := time.Since(e.lastCollection); age > maxAge {
+		return fmt.Errorf("last successful collection finished %s ago, more than %s", age.Round(time.Second), maxAge)
+	}
+	return nil
+}
+
+// status returns a snapshot of the exporter state
+func (e *exporter) status() exporterStatus {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	status := exporterStatus{
+		ChainID:    e.collector.ChainID(),
+		SDKVersion: string(e.collector.SDKVersion()),
+		Ready:      e.checkCollection() == nil,
+		Collectors: make(map[string]collectorStatus, len(e.collectors)),
+	}
+	if !e.lastCollection.IsZero() {
+		lastCollection := e.lastCollection
+		status.LastCollection = &lastCollection
+	}
+	for name, s := range e.collectors {
+		status.Collectors[name] = s
+	}
+	return status
+}
+
+// registerHealthHandlers adds the liveness, readiness and status endpoints to mux
+func registerHealthHandlers(mux *http.ServeMux, exp *exporter) {
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if err := exp.checkReady(r.Context()); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(exp.status()); err != nil {
+			log.Printf("Error writing status: %v", err)
+		}
+	})
+}
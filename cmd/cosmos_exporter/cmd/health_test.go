@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/forbole/cosmos-exporter/collector"
+	types "github.com/forbole/cosmos-exporter/types"
+	Config "github.com/forbole/cosmos-exporter/types/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// fakeStatus is the CometBFT status returned by the fake node
+const fakeStatus = `{
+	"node_info": {"protocol_version": {"p2p": "8", "block": "11", "app": "0"}, "network": "test-1", "other": {}},
+	"sync_info": {"latest_block_height": "100", "latest_block_time": "2026-01-01T00:00:00Z", "earliest_block_height": "1", "earliest_block_time": "2025-01-01T00:00:00Z", "catching_up": false},
+	"validator_info": {"voting_power": "0"}
+}`
+
+// newFakeNode starts a CometBFT RPC server answering the status of the
+// chain test-1 while up is set. Its gRPC endpoint refuses connections.
+func newFakeNode(t *testing.T) (node types.Node, up *atomic.Bool) {
+	t.Helper()
+
+	up = &atomic.Bool{}
+	up.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up.Load() {
+			http.Error(w, "down", http.StatusBadGateway)
+			return
+		}
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  json.RawMessage(fakeStatus),
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return types.Node{RPC: server.URL, GRPC: "127.0.0.1:1"}, up
+}
+
+// newTestExporter returns an exporter collecting from node, without running
+// any collection
+func newTestExporter(t *testing.T, cfg Config.Config) *exporter {
+	t.Helper()
+
+	grpcConn, err := grpc.Dial(cfg.Node.GRPC, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { grpcConn.Close() })
+	rpcClient, err := newRPCClient(cfg.Node)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &exporter{
+		cfg:        cfg,
+		grpcConn:   grpcConn,
+		collector:  collector.NewCosmosSDKCollector(context.Background(), grpcConn, rpcClient, collectorOptions(cfg)),
+		inFlight:   &sync.WaitGroup{},
+		reloadCh:   make(chan Config.Config, 1),
+		collectors: make(map[string]collectorStatus),
+	}
+}
+
+func TestReadyz(t *testing.T) {
+	succeeded := collector.CollectorResult{Name: "balances", Start: time.Now(), Duration: time.Second}
+	failed := collector.CollectorResult{Name: "balances", Start: time.Now(), Duration: time.Second, Err: errors.New("unavailable")}
+
+	tests := []struct {
+		name           string
+		results        []collector.CollectorResult
+		age            time.Duration
+		staleIntervals int
+		nodeDown       bool
+		wantCode       int
+	}{
+		{"no collection yet", nil, 0, 0, false, http.StatusServiceUnavailable},
+		{"every collector failed", []collector.CollectorResult{failed}, 0, 0, false, http.StatusServiceUnavailable},
+		{"fresh collection", []collector.CollectorResult{failed, succeeded}, 0, 0, false, http.StatusOK},
+		{"within the default stale window", []collector.CollectorResult{succeeded}, 2 * collectInterval, 0, false, http.StatusOK},
+		{"beyond the default stale window", []collector.CollectorResult{succeeded}, 3*collectInterval + time.Minute, 0, false, http.StatusServiceUnavailable},
+		{"beyond a configured stale window", []collector.CollectorResult{succeeded}, collectInterval + time.Minute, 1, false, http.StatusServiceUnavailable},
+		{"within a configured stale window", []collector.CollectorResult{succeeded}, 4 * collectInterval, 5, false, http.StatusOK},
+		{"node unreachable", []collector.CollectorResult{succeeded}, 0, 0, true, http.StatusServiceUnavailable},
+	}
+
+	node, up := newFakeNode(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up.Store(true)
+			exp := newTestExporter(t, Config.Config{Node: node, Health: types.NewHealth(tt.staleIntervals)})
+			exp.record(tt.results)
+			if !exp.lastCollection.IsZero() {
+				exp.lastCollection = exp.lastCollection.Add(-tt.age)
+			}
+			up.Store(!tt.nodeDown)
+
+			mux := http.NewServeMux()
+			registerHealthHandlers(mux, exp)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if rec.Code != tt.wantCode {
+				t.Errorf("/readyz = %d %q, want %d", rec.Code, rec.Body.String(), tt.wantCode)
+			}
+		})
+	}
+}
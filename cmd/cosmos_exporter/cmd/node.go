@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	cmthttp "github.com/cometbft/cometbft/rpc/client/http"
+	jsonrpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	"github.com/forbole/cosmos-exporter/collector"
+	types "github.com/forbole/cosmos-exporter/types"
+	Config "github.com/forbole/cosmos-exporter/types/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// dialNode opens a gRPC connection to the node configured in the config file.
+// Each query is retried on retryable errors and, for every attempt, waits for
+// one of the concurrency.max_requests slots, goes through the circuit breaker
+// of the endpoint and is recorded in the self metrics.
+func dialNode(cfg Config.Config) (*grpc.ClientConn, error) {
+	address := HTTPProtocols.ReplaceAllString(cfg.Node.GRPC, "")
+
+	retry, err := collector.Retry(cfg.Retry)
+	if err != nil {
+		return nil, err
+	}
+	interceptors := []grpc.UnaryClientInterceptor{
+		retry,
+		collector.LimitConcurrency(cfg.Concurrency.WithDefaults().MaxRequests),
+	}
+	if cfg.CircuitBreaker.IsEnabled() {
+		interceptors = append(interceptors, collector.CircuitBreaker(address, cfg.CircuitBreaker))
+	}
+	interceptors = append(interceptors, collector.InstrumentGRPC())
+
+	return dial(cfg, grpc.WithChainUnaryInterceptor(interceptors...))
+}
+
+// dialNodeDirect opens a gRPC connection to the node without retries,
+// concurrency limit, circuit breaker nor self metrics, so that each query
+// reports the answer of the node to a single attempt.
+func dialNodeDirect(cfg Config.Config) (*grpc.ClientConn, error) {
+	return dial(cfg)
+}
+
+// dial opens a gRPC connection to the node with the TLS and auth settings of
+// cfg and the given options
+func dial(cfg Config.Config, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	address := HTTPProtocols.ReplaceAllString(cfg.Node.GRPC, "")
+	grpcOpts := opts
+
+	if cfg.Node.IsSecure {
+		tlsConfig, err := nodeTLSConfig(cfg.Node.TLS)
+		if err != nil {
+			return nil, err
+		}
+		grpcOpts = append(grpcOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		grpcOpts = append(grpcOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	headers, err := nodeAuthHeaders(cfg.Node.Auth)
+	if err != nil {
+		return nil, err
+	}
+	if len(headers) > 0 {
+		if err := checkPlaintextAuth(address, cfg.Node.IsSecure, cfg.Node.Auth); err != nil {
+			return nil, err
+		}
+		grpcOpts = append(grpcOpts, grpc.WithPerRPCCredentials(headerCredentials(headers)))
+	}
+
+	return grpc.Dial(address, grpcOpts...)
+}
+
+// newRPCClient creates the CometBFT RPC client of the node, using the TLS
+// settings for https endpoints and sending the auth headers with every call
+func newRPCClient(node types.Node) (*cmthttp.HTTP, error) {
+	httpClient, err := jsonrpcclient.DefaultHTTPClient(node.RPC)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, ok := httpClient.Transport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected transport %T of the RPC client", httpClient.Transport)
+	}
+	transport.TLSClientConfig, err = nodeTLSConfig(node.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	headers, err := nodeAuthHeaders(node.Auth)
+	if err != nil {
+		return nil, err
+	}
+	if len(headers) > 0 {
+		secure := strings.HasPrefix(strings.ToLower(node.RPC), "https://")
+		if err := checkPlaintextAuth(node.RPC, secure, node.Auth); err != nil {
+			return nil, err
+		}
+		httpClient.Transport = &headerTransport{headers: headers, base: transport}
+	}
+
+	return cmthttp.NewWithClient(node.RPC, "/websocket", httpClient)
+}
+
+// nodeTLSConfig builds the TLS config shared by the gRPC and the RPC connections
+func nodeTLSConfig(cfg types.NodeTLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		caPEM, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading node CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificate found in node CA file %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading node client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// nodeAuthHeaders resolves the headers sent to the node, reading the secrets
+// stored in files. Keys are lower-cased as required by gRPC metadata.
+func nodeAuthHeaders(auth types.NodeAuth) (map[string]string, error) {
+	headers := make(map[string]string)
+	for key, value := range auth.Headers {
+		headers[strings.ToLower(key)] = value
+	}
+	for key, file := range auth.HeaderFiles {
+		value, err := readSecret(file)
+		if err != nil {
+			return nil, err
+		}
+		headers[strings.ToLower(key)] = value
+	}
+
+	token := auth.BearerToken
+	if auth.TokenFile != "" {
+		var err error
+		if token, err = readSecret(auth.TokenFile); err != nil {
+			return nil, err
+		}
+	}
+
+	password := auth.Password
+	if auth.PasswordFile != "" {
+		var err error
+		if password, err = readSecret(auth.PasswordFile); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case token != "" && auth.Username != "":
+		return nil, fmt.Errorf("node auth: bearer token and basic auth can not be used together")
+	case token != "":
+		headers["authorization"] = "Bearer " + token
+	case auth.Username != "":
+		credentials := base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + password))
+		headers["authorization"] = "Basic " + credentials
+	}
+
+	return headers, nil
+}
+
+// checkPlaintextAuth refuses to send credentials to a plaintext endpoint
+// unless node.auth.insecure is set, in which case it only warns
+func checkPlaintextAuth(endpoint string, secure bool, auth types.NodeAuth) error {
+	if secure {
+		return nil
+	}
+	if !auth.Insecure {
+		return fmt.Errorf("node auth: refusing to send credentials over plaintext to %s, enable TLS or set node.auth.insecure", endpoint)
+	}
+	log.Printf("Warning: node credentials are sent over plaintext to %s", endpoint)
+	return nil
+}
+
+// readSecret reads a secret from a file, ignoring the trailing new line
+func readSecret(file string) (string, error) {
+	secret, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("error reading secret file: %w", err)
+	}
+	return strings.TrimRight(string(secret), "\r\n"), nil
+}
+
+// headerCredentials sends static headers as metadata of every gRPC query
+type headerCredentials map[string]string
+
+func (h headerCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return h, nil
+}
+
+// RequireTransportSecurity returns false so that credentials can also be
+// sent to plaintext endpoints when node.auth.insecure is set, see
+// checkPlaintextAuth
+func (h headerCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// headerTransport sets static headers on every RPC request
+type headerTransport struct {
+	headers map[string]string
+	base    http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for key, value := range t.headers {
+		req.Header.Set(key, value)
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	types "github.com/forbole/cosmos-exporter/types"
+	Config "github.com/forbole/cosmos-exporter/types/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// writeTestCert writes a self-signed certificate for 127.0.0.1 and its key
+// to dir, the certificate is also its own CA
+func writeTestCert(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "node"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	writeTestFile(t, certFile, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+	writeTestFile(t, keyFile, string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})))
+	return certFile, keyFile
+}
+
+func writeTestFile(t *testing.T, file, content string) {
+	t.Helper()
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNodeTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCert(t, dir)
+	emptyFile := filepath.Join(dir, "empty.pem")
+	writeTestFile(t, emptyFile, "")
+
+	tests := []struct {
+		name       string
+		cfg        types.NodeTLS
+		wantErr    bool
+		wantCAs    bool
+		wantClient bool
+	}{
+		{"defaults", types.NodeTLS{}, false, false, false},
+		{"CA file", types.NodeTLS{CAFile: certFile}, false, true, false},
+		{"client certificate", types.NodeTLS{CertFile: certFile, KeyFile: keyFile}, false, false, true},
+		{"missing CA file", types.NodeTLS{CAFile: filepath.Join(dir, "missing.pem")}, true, false, false},
+		{"CA file without certificate", types.NodeTLS{CAFile: emptyFile}, true, false, false},
+		{"client certificate without key", types.NodeTLS{CertFile: certFile}, true, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tlsConfig, err := nodeTLSConfig(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("nodeTLSConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if (tlsConfig.RootCAs != nil) != tt.wantCAs {
+				t.Errorf("root CAs set = %v, want %v", tlsConfig.RootCAs != nil, tt.wantCAs)
+			}
+			if (len(tlsConfig.Certificates) > 0) != tt.wantClient {
+				t.Errorf("client certificates = %d, want one %v", len(tlsConfig.Certificates), tt.wantClient)
+			}
+		})
+	}
+
+	tlsConfig, err := nodeTLSConfig(types.NodeTLS{ServerName: "node.example", InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsConfig.ServerName != "node.example" || !tlsConfig.InsecureSkipVerify {
+		t.Errorf("server name = %s, insecure skip verify = %v, want node.example and true", tlsConfig.ServerName, tlsConfig.InsecureSkipVerify)
+	}
+}
+
+func TestNodeAuthHeaders(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	writeTestFile(t, tokenFile, "file-token\n")
+	passwordFile := filepath.Join(dir, "password")
+	writeTestFile(t, passwordFile, "file-password\r\n")
+	keyFile := filepath.Join(dir, "key")
+	writeTestFile(t, keyFile, "file-key\n")
+
+	basic := func(username, password string) string {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+	}
+	tests := []struct {
+		name    string
+		auth    types.NodeAuth
+		want    map[string]string
+		wantErr bool
+	}{
+		{"none", types.NodeAuth{}, map[string]string{}, false},
+		{"headers are lower-cased", types.NodeAuth{Headers: map[string]string{"X-API-Key": "key"}}, map[string]string{"x-api-key": "key"}, false},
+		{"header file", types.NodeAuth{HeaderFiles: map[string]string{"X-API-Key": keyFile}}, map[string]string{"x-api-key": "file-key"}, false},
+		{"bearer token", types.NodeAuth{BearerToken: "token"}, map[string]string{"authorization": "Bearer token"}, false},
+		{"bearer token file", types.NodeAuth{BearerToken: "token", TokenFile: tokenFile}, map[string]string{"authorization": "Bearer file-token"}, false},
+		{"basic auth", types.NodeAuth{Username: "user", Password: "password"}, map[string]string{"authorization": basic("user", "password")}, false},
+		{"basic auth password file", types.NodeAuth{Username: "user", PasswordFile: passwordFile}, map[string]string{"authorization": basic("user", "file-password")}, false},
+		{"bearer token and basic auth", types.NodeAuth{BearerToken: "token", Username: "user"}, nil, true},
+		{"missing secret file", types.NodeAuth{TokenFile: filepath.Join(dir, "missing")}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers, err := nodeAuthHeaders(tt.auth)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("nodeAuthHeaders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(headers, tt.want) {
+				t.Errorf("nodeAuthHeaders() = %v, want %v", headers, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeaderTransportTLS(t *testing.T) {
+	certFile, keyFile := writeTestCert(t, t.TempDir())
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotAuth string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("authorization")
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	server.StartTLS()
+	defer server.Close()
+
+	tlsConfig, err := nodeTLSConfig(types.NodeTLS{CAFile: certFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: &headerTransport{
+		headers: map[string]string{"authorization": "Bearer token"},
+		base:    &http.Transport{TLSClientConfig: tlsConfig},
+	}}
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("the node certificate was not trusted through the CA file: %v", err)
+	}
+	res.Body.Close()
+	if gotAuth != "Bearer token" {
+		t.Errorf("authorization header = %q, want the bearer token", gotAuth)
+	}
+}
+
+func TestNewRPCClientPlaintextAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		node    types.Node
+		wantErr bool
+	}{
+		{"plaintext without credentials", types.Node{RPC: "http://localhost:26657"}, false},
+		{"plaintext with credentials", types.Node{RPC: "http://localhost:26657", Auth: types.NodeAuth{BearerToken: "token"}}, true},
+		{"plaintext with credentials allowed", types.Node{RPC: "http://localhost:26657", Auth: types.NodeAuth{BearerToken: "token", Insecure: true}}, false},
+		{"TLS with credentials", types.Node{RPC: "https://localhost:26657", Auth: types.NodeAuth{BearerToken: "token"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newRPCClient(tt.node)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newRPCClient() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// unavailableStaking fails every Params query as Unavailable and counts them
+type unavailableStaking struct {
+	stakingtypes.UnimplementedQueryServer
+	calls atomic.Int32
+}
+
+func (s *unavailableStaking) Params(ctx context.Context, req *stakingtypes.QueryParamsRequest) (*stakingtypes.QueryParamsResponse, error) {
+	s.calls.Add(1)
+	return nil, status.Error(codes.Unavailable, "node down")
+}
+
+func TestDialNodeRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		dial      func(cfg Config.Config) (*grpc.ClientConn, error)
+		wantCalls int32
+	}{
+		{"exporter connection retries", dialNode, 2},
+		{"direct connection does not retry", dialNodeDirect, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			staking := &unavailableStaking{}
+			server := grpc.NewServer()
+			stakingtypes.RegisterQueryServer(server, staking)
+			go server.Serve(lis)
+			defer server.Stop()
+
+			cfg := Config.Config{
+				Node:  types.Node{GRPC: lis.Addr().String()},
+				Retry: types.Retry{MaxAttempts: 2, InitialBackoff: time.Millisecond},
+			}
+			conn, err := tt.dial(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			_, err = stakingtypes.NewQueryClient(conn).Params(context.Background(), &stakingtypes.QueryParamsRequest{})
+			if status.Code(err) != codes.Unavailable {
+				t.Fatalf("error = %v, want Unavailable", err)
+			}
+			if got := staking.calls.Load(); got != tt.wantCalls {
+				t.Errorf("%d queries reached the node, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/forbole/cosmos-exporter/collector"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// registerProbeHandler adds the /probe endpoint to mux, which collects the
+// metrics of the address and module given as query parameters on demand
+func registerProbeHandler(mux *http.ServeMux, exp *exporter, filter *collector.MetricsFilter) {
+	mux.HandleFunc("/probe", func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		address := params.Get("address")
+		if address == "" {
+			http.Error(w, "address parameter is missing", http.StatusBadRequest)
+			return
+		}
+
+		c, release := exp.acquireCollector()
+		defer release()
+		if chain := params.Get("chain"); chain != "" && chain != c.ChainID() {
+			http.Error(w, fmt.Sprintf("chain %q is not configured, the exporter monitors %q", chain, c.ChainID()), http.StatusBadRequest)
+			return
+		}
+
+		registry, err := c.Probe(r.Context(), params.Get("module"), address)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		promhttp.HandlerFor(filter.Wrap(registry), promhttp.HandlerOpts{}).ServeHTTP(w, r)
+	})
+}
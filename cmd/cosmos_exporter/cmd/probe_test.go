@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/forbole/cosmos-exporter/collector"
+	types "github.com/forbole/cosmos-exporter/types"
+	Config "github.com/forbole/cosmos-exporter/types/config"
+)
+
+func TestProbe(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+		wantBody string
+	}{
+		{"missing address", "module=balance", http.StatusBadRequest, "address parameter is missing"},
+		{"unknown chain", "module=balance&address=cosmos1test&chain=other-1", http.StatusBadRequest, `chain "other-1" is not configured`},
+		{"unknown module", "module=staking&address=cosmos1test", http.StatusBadRequest, `unknown module "staking"`},
+		{"missing module", "address=cosmos1test", http.StatusBadRequest, `unknown module ""`},
+		{"failed probe", "module=balance&address=cosmos1test&chain=test-1", http.StatusOK, `probe_success{env="test"} 0`},
+	}
+
+	node, _ := newFakeNode(t)
+	exp := newTestExporter(t, Config.Config{Node: node})
+	filter, err := collector.NewMetricsFilter(types.Metrics{ConstLabels: map[string]string{"env": "test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	registerProbeHandler(mux, exp, filter)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/probe?"+tt.query, nil))
+			if rec.Code != tt.wantCode {
+				t.Errorf("/probe?%s = %d, want %d", tt.query, rec.Code, tt.wantCode)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("/probe?%s body = %q, want it to contain %q", tt.query, rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
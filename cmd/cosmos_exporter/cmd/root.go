@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"os"
+	"os/signal"
 	"path"
+	"syscall"
 
 	Config "github.com/forbole/cosmos-exporter/types/config"
 	homedir "github.com/mitchellh/go-homedir"
@@ -23,8 +27,12 @@ var rootCmd = &cobra.Command{
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// The context passed to the commands is cancelled on SIGINT or SIGTERM.
 func Execute() {
-	handleInitError(rootCmd.Execute())
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	handleInitError(err)
 }
 
 func init() {
@@ -45,6 +53,24 @@ func initConfig() {
 	}
 }
 
+// loadConfig reads the config file, applies environment variable and flag
+// overrides of the given command and unmarshals the result into config
+func loadConfig(cmd *cobra.Command) error {
+	if err := bindConfigOverrides(cmd.Flags()); err != nil {
+		return err
+	}
+
+	if err := viper.ReadInConfig(); err != nil { // Handle errors reading the config file
+		// Without --home the config file is optional, everything can be set by env or flags
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return fmt.Errorf("Fatal error config file: %w \n", err)
+		}
+		log.Printf("No config file found, using environment variables and flags only")
+	}
+	config = &Config.Config{}
+	return unmarshalConfig(config)
+}
+
 func handleInitError(err error) {
 	if err != nil {
 		fmt.Println(err)
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	types "github.com/forbole/cosmos-exporter/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	content := []byte(`
+validator_address: cosmosvaloper1file
+port: ":1000"
+node:
+  rpc: http://file:26657
+  grpc: file:9090
+denom_metadata:
+  base_denom: ufile
+  display_denom: file
+  exponent: 6
+`)
+	if err := os.WriteFile(file, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		env   map[string]string
+		flags map[string]string
+		check func(t *testing.T)
+	}{
+		{
+			name: "config file",
+			check: func(t *testing.T) {
+				if config.ValidatorAddress != "cosmosvaloper1file" || config.Port != ":1000" || config.Node.GRPC != "file:9090" {
+					t.Errorf("config = %+v, want the values of the config file", config)
+				}
+			},
+		},
+		{
+			name: "environment variable over config file",
+			env:  map[string]string{"COSMOS_EXPORTER_PORT": ":2000", "COSMOS_EXPORTER_DENOM_METADATA_EXPONENT": "18"},
+			check: func(t *testing.T) {
+				if config.Port != ":2000" || config.DenomMetadata.Exponent != 18 {
+					t.Errorf("port = %s, exponent = %d, want :2000 and 18 from the environment", config.Port, config.DenomMetadata.Exponent)
+				}
+				if config.DenomMetadata.Base != "ufile" {
+					t.Errorf("base denom = %s, want ufile from the config file", config.DenomMetadata.Base)
+				}
+			},
+		},
+		{
+			name:  "flag over environment variable",
+			env:   map[string]string{"COSMOS_EXPORTER_PORT": ":2000", "COSMOS_EXPORTER_NODE_GRPC": "env:9090"},
+			flags: map[string]string{"port": ":3000"},
+			check: func(t *testing.T) {
+				if config.Port != ":3000" {
+					t.Errorf("port = %s, want :3000 from the flag", config.Port)
+				}
+				if config.Node.GRPC != "env:9090" {
+					t.Errorf("gRPC = %s, want env:9090 from the environment", config.Node.GRPC)
+				}
+			},
+		},
+		{
+			name: "key missing from the config file",
+			env:  map[string]string{"COSMOS_EXPORTER_DELEGATOR_ADDRESSES": "cosmos1a,cosmos1b"},
+			check: func(t *testing.T) {
+				if want := []string{"cosmos1a", "cosmos1b"}; !reflect.DeepEqual(config.DelegatorAddresses, want) {
+					t.Errorf("delegator addresses = %v, want %v", config.DelegatorAddresses, want)
+				}
+			},
+		},
+		{
+			name: "map keys from JSON environment variables",
+			env: map[string]string{
+				"COSMOS_EXPORTER_LABELS_ADDRESSES":    `{"cosmos1a": {"alias": "treasury", "team": "ops"}}`,
+				"COSMOS_EXPORTER_BALANCES_THRESHOLDS": `{"cosmos1a": {"atom": 12.5}}`,
+			},
+			check: func(t *testing.T) {
+				if want := (types.AddressLabels{Alias: "treasury", Team: "ops"}); config.Labels.Addresses["cosmos1a"] != want {
+					t.Errorf("address labels = %+v, want %+v", config.Labels.Addresses, want)
+				}
+				if got := config.Balances.Thresholds["cosmos1a"]["atom"]; got != 12.5 {
+					t.Errorf("threshold = %v, want 12.5", got)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			viper.SetConfigFile(file)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			cmd := &cobra.Command{}
+			addConfigFlags(cmd)
+			for name, value := range tt.flags {
+				if err := cmd.Flags().Set(name, value); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			config = nil
+			if err := loadConfig(cmd); err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t)
+		})
+	}
+}
@@ -1,27 +1,37 @@
 package cmd
 
 import (
-	"crypto/tls"
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
 	"regexp"
+	"syscall"
 	"time"
 
-	"github.com/forbole/cosmos-exporter/collector"
+	Config "github.com/forbole/cosmos-exporter/types/config"
+	"github.com/fsnotify/fsnotify"
+	kitlog "github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/exporter-toolkit/web"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
+// shutdownTimeout bounds how long in-flight scrapes are waited for on shutdown
+const shutdownTimeout = 10 * time.Second
+
 var (
 	HTTPProtocols = regexp.MustCompile("https?://")
 )
 
 func init() {
+	addConfigFlags(startCmd)
 	rootCmd.AddCommand(startCmd)
 }
 
@@ -29,43 +39,183 @@ var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start exporting cosmos metrics",
 	PreRunE: func(cmd *cobra.Command, args []string) error {
-		if err := viper.ReadInConfig(); err != nil { // Handle errors reading the config file
-			panic(fmt.Errorf("Fatal error config file: %w \n", err))
-		}
-		err := viper.Unmarshal(&config)
-		if err != nil {
-			return err
-		}
-		return nil
+		return loadConfig(cmd)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var grpcOpts []grpc.DialOption
-
-		if config.Node.IsSecure {
-			grpcOpts = append(grpcOpts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
-				InsecureSkipVerify: false,
-			})))
-		} else {
-			grpcOpts = append(grpcOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		ctx := cmd.Context()
+
+		if config.WebConfigFile != "" {
+			if err := web.Validate(config.WebConfigFile); err != nil {
+				return fmt.Errorf("invalid web config file: %w", err)
+			}
 		}
 
-		address := HTTPProtocols.ReplaceAllString(config.Node.GRPC, "")
-		grpcConn, err := grpc.Dial(address, grpcOpts...)
+		exp, err := newExporter(ctx, *config)
 		if err != nil {
-			panic(err)
+			return fmt.Errorf("create exporter: %w", err)
+		}
+		defer exp.close()
+
+		if err := watchConfig(ctx, exp); err != nil {
+			return err
 		}
-		defer grpcConn.Close()
 
-		cosmosSDKCollector := collector.NewCosmosSDKCollector(grpcConn, config.Node.RPC, config.ValidatorAddress, config.DelegatorAddresses, config.DenomMetadata)
+		collectDone := make(chan struct{})
 		go func() {
-			for {
-				cosmosSDKCollector.CollectChainMetrics()
-				time.Sleep(10 * time.Minute)
-			}
+			defer close(collectDone)
+			exp.run(ctx)
 		}()
-		http.Handle("/metrics", promhttp.Handler())
-		log.Printf("Start listening on port %s", config.Port)
-		log.Fatal(http.ListenAndServe(config.Port, nil))
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.InstrumentMetricHandler(
+			prometheus.DefaultRegisterer,
+			promhttp.HandlerFor(exp.metricsFilter.Wrap(prometheus.DefaultGatherer), promhttp.HandlerOpts{}),
+		))
+		registerHealthHandlers(mux, exp)
+		registerProbeHandler(mux, exp, exp.metricsFilter)
+		server := &http.Server{Addr: config.Port, Handler: mux}
+
+		serverErr := make(chan error, 1)
+		go func() {
+			log.Printf("Start listening on port %s", config.Port)
+			serverErr <- serveWeb(server, config.WebConfigFile)
+		}()
+
+		select {
+		case err := <-serverErr:
+			return err
+		case <-ctx.Done():
+		}
+
+		log.Printf("Shutting down")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Error shutting down the metrics server: %v", err)
+		}
+
+		// Wait for in-flight queries to be cancelled before closing the connection
+		<-collectDone
 		return nil
 	},
 }
+
+// serveWeb serves the metrics server, with TLS and basic auth when a web
+// config file in the Prometheus exporter-toolkit format is set
+func serveWeb(server *http.Server, webConfigFile string) error {
+	listenAddresses := []string{server.Addr}
+	systemdSocket := false
+	flags := &web.FlagConfig{
+		WebListenAddresses: &listenAddresses,
+		WebSystemdSocket:   &systemdSocket,
+		WebConfigFile:      &webConfigFile,
+	}
+	logger := kitlog.NewLogfmtLogger(kitlog.NewSyncWriter(log.Writer()))
+	return web.ListenAndServe(server, flags, logger)
+}
+
+// watchConfig reloads the exporter when the config file changes or on SIGHUP.
+// Both triggers go through a single goroutine, the only one using viper once
+// the exporter started.
+func watchConfig(ctx context.Context, exp *exporter) error {
+	triggers := make(chan string, 1)
+	trigger := func(reason string) {
+		select {
+		case triggers <- reason:
+		default: // A reload is already pending and will read the latest file
+		}
+	}
+
+	if file := viper.ConfigFileUsed(); file != "" {
+		if err := watchConfigFile(ctx, file, trigger); err != nil {
+			return err
+		}
+	}
+
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				signal.Stop(sighup)
+				return
+			case <-sighup:
+				trigger("Received SIGHUP")
+			}
+		}
+	}()
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case reason := <-triggers:
+				log.Printf("%s, reloading config", reason)
+				reloadConfig(exp)
+			}
+		}
+	}()
+	return nil
+}
+
+// watchConfigFile calls trigger when file is written or replaced, watching
+// its directory so that editors and Kubernetes config maps swapping the
+// file or its symlink are also seen
+func watchConfigFile(ctx context.Context, file string, trigger func(reason string)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("watch config file: %w", err)
+	}
+	file = filepath.Clean(file)
+	if err := watcher.Add(filepath.Dir(file)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("watch config file: %w", err)
+	}
+
+	realFile, _ := filepath.EvalSymlinks(file)
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				currentFile, _ := filepath.EvalSymlinks(file)
+				written := filepath.Clean(event.Name) == file && event.Op&(fsnotify.Write|fsnotify.Create) != 0
+				if written || (currentFile != "" && currentFile != realFile) {
+					realFile = currentFile
+					trigger(fmt.Sprintf("Config file %s changed", file))
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("Error watching config file %s: %v", file, err)
+			}
+		}
+	}()
+	return nil
+}
+
+// reloadConfig reads the config file again, decodes the config and hands it
+// to the exporter. It is only called by the reload goroutine of watchConfig.
+func reloadConfig(exp *exporter) {
+	if viper.ConfigFileUsed() != "" {
+		if err := viper.ReadInConfig(); err != nil {
+			log.Printf("Error reloading config, keeping current one: %v", err)
+			return
+		}
+	}
+
+	var cfg Config.Config
+	if err := unmarshalConfig(&cfg); err != nil {
+		log.Printf("Error reloading config, keeping current one: %v", err)
+		return
+	}
+	exp.Reload(cfg)
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestWatchConfigFile(t *testing.T) {
+	// Some hosts run out of inotify instances
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Skipf("file watching unavailable: %v", err)
+	}
+	watcher.Close()
+
+	tests := []struct {
+		name      string
+		symlinked bool
+		change    func(t *testing.T, dir, file string)
+	}{
+		{
+			name: "file written",
+			change: func(t *testing.T, dir, file string) {
+				writeTestFile(t, file, "port: \":2000\"\n")
+			},
+		},
+		{
+			name: "file replaced",
+			change: func(t *testing.T, dir, file string) {
+				next := filepath.Join(dir, "config.yaml.new")
+				writeTestFile(t, next, "port: \":2000\"\n")
+				if err := os.Rename(next, file); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name:      "symlink target swapped",
+			symlinked: true,
+			change: func(t *testing.T, dir, file string) {
+				// Kubernetes config maps swap the ..data symlink
+				target := filepath.Join(dir, "v2")
+				if err := os.Mkdir(target, 0o755); err != nil {
+					t.Fatal(err)
+				}
+				writeTestFile(t, filepath.Join(target, "config.yaml"), "port: \":2000\"\n")
+				if err := os.Symlink("v2", filepath.Join(dir, "..data.tmp")); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Rename(filepath.Join(dir, "..data.tmp"), filepath.Join(dir, "..data")); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			file := filepath.Join(dir, "config.yaml")
+			if tt.symlinked {
+				if err := os.Mkdir(filepath.Join(dir, "v1"), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				writeTestFile(t, filepath.Join(dir, "v1", "config.yaml"), "port: \":1000\"\n")
+				if err := os.Symlink("v1", filepath.Join(dir, "..data")); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Symlink(filepath.Join("..data", "config.yaml"), file); err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				writeTestFile(t, file, "port: \":1000\"\n")
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			triggered := make(chan string, 10)
+			if err := watchConfigFile(ctx, file, func(reason string) { triggered <- reason }); err != nil {
+				t.Fatal(err)
+			}
+
+			tt.change(t, dir, file)
+
+			select {
+			case <-triggered:
+			case <-time.After(5 * time.Second):
+				t.Fatal("config change not seen")
+			}
+		})
+	}
+}
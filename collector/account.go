@@ -0,0 +1,138 @@
+package collector
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// accountHistory keeps the sequence of each monitored address seen by the
+// previous collection, to compute its rate and the time since it changed
+type accountHistory struct {
+	mu       sync.Mutex
+	accounts map[string]accountObservation
+}
+
+type accountObservation struct {
+	sequence   uint64
+	observedAt time.Time
+	changedAt  time.Time
+}
+
+// observe records the sequence of address and returns the previous
+// observation, false for the first one
+func (h *accountHistory) observe(address string, sequence uint64, now time.Time) (accountObservation, accountObservation, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.accounts == nil {
+		h.accounts = make(map[string]accountObservation)
+	}
+	previous, found := h.accounts[address]
+	current := accountObservation{sequence: sequence, observedAt: now, changedAt: now}
+	if found && previous.sequence == sequence {
+		current.changedAt = previous.changedAt
+	}
+	h.accounts[address] = current
+	return previous, current, found
+}
+
+// prune forgets the addresses which are no longer monitored
+func (h *accountHistory) prune(addresses []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	monitored := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		monitored[address] = true
+	}
+	for address := range h.accounts {
+		if !monitored[address] {
+			delete(h.accounts, address)
+		}
+	}
+}
+
+// CollectAccounts exports the account number and sequence of the monitored
+// addresses, with the rate of the sequence and the time since it changed so
+// bots which stopped sending transactions can be noticed
+func (collector *CosmosSDKCollector) CollectAccounts(ctx context.Context) error {
+	defer collector.accounts.prune(collector.delegatorAddresses())
+
+	return collector.forEachAddress(ctx, func(ctx context.Context, address string) error {
+		accountNumber, sequence, found, err := collector.queryAccountSequence(ctx, address)
+		if err != nil {
+			ErrorGauge.WithLabelValues("cosmos_auth_account_sequence").Inc()
+			log.Printf("Error getting the account %s: %v", address, err)
+			return err
+		}
+		// Addresses which never received tokens have no account
+		if !found {
+			return nil
+		}
+
+		labels := collector.addressLabels(address)
+		withLabelValues(ctx, AccountNumberGauge, collector.chainID, address, labels.Alias, labels.Owner, labels.Team).Set(float64(accountNumber))
+		withLabelValues(ctx, AccountSequenceGauge, collector.chainID, address, labels.Alias, labels.Owner, labels.Team).Set(float64(sequence))
+
+		now := time.Now()
+		previous, current, found := collector.accounts.observe(address, sequence, now)
+		withLabelValues(ctx, AccountSequenceUnchangedGauge, collector.chainID, address, labels.Alias, labels.Owner, labels.Team).Set(now.Sub(current.changedAt).Seconds())
+
+		// The rate needs a previous collection
+		if !found || current.sequence < previous.sequence {
+			return nil
+		}
+		elapsed := current.observedAt.Sub(previous.observedAt).Seconds()
+		if elapsed <= 0 {
+			return nil
+		}
+		withLabelValues(ctx, AccountSequenceRateGauge, collector.chainID, address, labels.Alias, labels.Owner, labels.Team).Set(float64(current.sequence-previous.sequence) / elapsed)
+		return nil
+	})
+}
+
+// queryAccountSequence returns the account number and sequence of address,
+// found is false when it has no account. AccountInfo returns a plain base
+// account, so custom account types of the chain need no decoding.
+func (collector *CosmosSDKCollector) queryAccountSequence(ctx context.Context, address string) (accountNumber, sequence uint64, found bool, err error) {
+	authClient := authtypes.NewQueryClient(collector.grpcConn)
+
+	infoCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	infoRes, err := authClient.AccountInfo(infoCtx, &authtypes.QueryAccountInfoRequest{Address: address})
+	switch status.Code(err) {
+	case codes.OK:
+		return infoRes.Info.GetAccountNumber(), infoRes.Info.GetSequence(), true, nil
+	case codes.NotFound:
+		return 0, 0, false, nil
+	case codes.Unimplemented:
+		// Chains before v0.47 only have the Account query
+	default:
+		return 0, 0, false, err
+	}
+
+	accountCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	accountRes, err := authClient.Account(accountCtx, &authtypes.QueryAccountRequest{Address: address})
+	if status.Code(err) == codes.NotFound {
+		return 0, 0, false, nil
+	}
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	var account sdk.AccountI
+	if err := interfaceRegistry.UnpackAny(accountRes.Account, &account); err != nil {
+		return 0, 0, false, err
+	}
+	return account.GetAccountNumber(), account.GetSequence(), true, nil
+}
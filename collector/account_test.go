@@ -0,0 +1,79 @@
+package collector
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAccountHistoryObserve(t *testing.T) {
+	start := time.Unix(1000, 0)
+	// step observes sequence after elapsed seconds
+	type step struct {
+		elapsed       int64
+		sequence      uint64
+		wantFound     bool
+		wantChangedAt int64
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"first observation", []step{
+			{0, 5, false, 1000},
+		}},
+		{"unchanged sequence keeps the change time", []step{
+			{0, 5, false, 1000},
+			{60, 5, true, 1000},
+			{120, 5, true, 1000},
+		}},
+		{"changed sequence moves the change time", []step{
+			{0, 5, false, 1000},
+			{60, 5, true, 1000},
+			{120, 7, true, 1120},
+			{180, 7, true, 1120},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var history accountHistory
+			var last accountObservation
+			for i, s := range tt.steps {
+				now := start.Add(time.Duration(s.elapsed) * time.Second)
+				previous, current, found := history.observe("addr", s.sequence, now)
+				if found != s.wantFound {
+					t.Fatalf("step %d: found = %v, want %v", i, found, s.wantFound)
+				}
+				if found && previous != last {
+					t.Errorf("step %d: previous = %+v, want %+v", i, previous, last)
+				}
+				if current.sequence != s.sequence || !current.observedAt.Equal(now) {
+					t.Errorf("step %d: current = %+v, want sequence %d observed at %s", i, current, s.sequence, now)
+				}
+				if got := current.changedAt.Unix(); got != s.wantChangedAt {
+					t.Errorf("step %d: changed at %d, want %d", i, got, s.wantChangedAt)
+				}
+				last = current
+			}
+		})
+	}
+}
+
+func TestAccountHistoryPrune(t *testing.T) {
+	var history accountHistory
+	now := time.Unix(1000, 0)
+	for _, address := range []string{"addr1", "addr2", "addr3"} {
+		history.observe(address, 1, now)
+	}
+
+	history.prune([]string{"addr1", "addr3"})
+
+	if len(history.accounts) != 2 {
+		t.Errorf("%d addresses kept, want 2", len(history.accounts))
+	}
+	if _, _, found := history.observe("addr2", 1, now); found {
+		t.Error("a pruned address was still known")
+	}
+	if _, _, found := history.observe("addr1", 1, now); !found {
+		t.Error("a monitored address was pruned")
+	}
+}
@@ -4,16 +4,17 @@ import (
 	"context"
 	"log"
 	"strconv"
-	"sync"
 
 	"github.com/cosmos/cosmos-sdk/x/gov/types/v1"
-	"github.com/prometheus/client_golang/prometheus"
 )
 
-func (collector *CosmosSDKCollector) CollectActiveProposal() {
+func (collector *CosmosSDKCollector) CollectActiveProposal(ctx context.Context) error {
+	queryCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
 	govClient := v1.NewQueryClient(collector.grpcConn)
 	govRes, err := govClient.Proposals(
-		context.Background(),
+		queryCtx,
 		&v1.QueryProposalsRequest{
 			ProposalStatus: v1.StatusVotingPeriod,
 		},
@@ -22,21 +23,9 @@ func (collector *CosmosSDKCollector) CollectActiveProposal() {
 	if err != nil {
 		ErrorGauge.WithLabelValues("tendermint_active_proposals_total").Inc()
 		log.Print(err)
-		return
+		return err
 	}
 
-	VotedActiveProposalGauge.DeletePartialMatch(
-		prometheus.Labels{
-			"chain_id": collector.chainID,
-		},
-	)
-
-	ActiveProposalGauge.DeletePartialMatch(
-		prometheus.Labels{
-			"chain_id": collector.chainID,
-		},
-	)
-
 	// Count proposals base on TypeUrl
 	countProposalType := make(map[string]float64)
 	for _, proposal := range govRes.Proposals {
@@ -45,34 +34,38 @@ func (collector *CosmosSDKCollector) CollectActiveProposal() {
 			msgTypeUrl = proposal.Messages[0].TypeUrl
 		}
 		countProposalType[msgTypeUrl] += 1
-		
+
 		// Vote status
-		var wg sync.WaitGroup
-		for _, address := range collector.accAddresses {
-			wg.Add(1)
-			go func(address string) {
-				defer wg.Done()
-				_, err := govClient.Vote(
-					context.Background(),
-					&v1.QueryVoteRequest{
-						ProposalId: proposal.Id,
-						Voter:      address,
-					},
-				)
+		err := collector.forEachAddress(ctx, func(ctx context.Context, address string) error {
+			ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+			defer cancel()
+
+			_, err := govClient.Vote(
+				ctx,
+				&v1.QueryVoteRequest{
+					ProposalId: proposal.Id,
+					Voter:      address,
+				},
+			)
+
+			labels := collector.addressLabels(address)
 
-				// When the voter_address hasn't voted, the query returns "not found for proposal" error
-				if err != nil {
-					VotedActiveProposalGauge.WithLabelValues(collector.chainID, address, strconv.FormatUint(proposal.Id, 10)).Set(float64(0))
-					return
-				}
+			// When the voter_address hasn't voted, the query returns "not found for proposal" error
+			if err != nil {
+				withLabelValues(ctx, VotedActiveProposalGauge, collector.chainID, address, strconv.FormatUint(proposal.Id, 10), labels.Alias, labels.Owner, labels.Team).Set(float64(0))
+				return nil
+			}
 
-				VotedActiveProposalGauge.WithLabelValues(collector.chainID, address, strconv.FormatUint(proposal.Id, 10)).Set(float64(1))
-			}(address)
+			withLabelValues(ctx, VotedActiveProposalGauge, collector.chainID, address, strconv.FormatUint(proposal.Id, 10), labels.Alias, labels.Owner, labels.Team).Set(float64(1))
+			return nil
+		})
+		if err != nil {
+			return err
 		}
-		wg.Wait()
 	}
 
 	for key, total := range countProposalType {
-		ActiveProposalGauge.WithLabelValues(collector.chainID, key).Set(float64(total))
+		withLabelValues(ctx, ActiveProposalGauge, collector.chainID, key).Set(float64(total))
 	}
+	return nil
 }
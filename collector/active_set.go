@@ -0,0 +1,102 @@
+package collector
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	sdkmath "cosmossdk.io/math"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	types "github.com/forbole/cosmos-exporter/types"
+)
+
+// CollectActiveSet exports the size of the active set, the tokens needed to
+// stay in it and how far the monitored validator is from its cutoff
+func (collector *CosmosSDKCollector) CollectActiveSet(ctx context.Context) error {
+	valAddress := collector.validatorAddress()
+	params, err := collector.stakingParams(ctx)
+	if err != nil {
+		ErrorGauge.WithLabelValues("cosmos_staking_max_validators").Inc()
+		log.Print(err)
+		return err
+	}
+	maxValidators := int(params.MaxValidators)
+	MaxValidatorsGauge.WithLabelValues(collector.chainID).Set(float64(maxValidators))
+
+	allValidators, err := collector.allValidators(ctx)
+	if err != nil {
+		ErrorGauge.WithLabelValues("cosmos_staking_active_set_cutoff_tokens").Inc()
+		log.Print(err)
+		return err
+	}
+	var validators []stakingtypes.Validator
+	for _, validator := range allValidators {
+		if validator.GetStatus() == stakingtypes.Bonded {
+			validators = append(validators, validator)
+		}
+	}
+
+	baseDenom, found := collector.denomMetadata[collector.defaultBondDenom]
+	if !found {
+		ErrorGauge.WithLabelValues("cosmos_staking_active_set_cutoff_tokens").Inc()
+		log.Print("No denom infos")
+		return &types.DenomNotFound{}
+	}
+
+	// Rank by tokens, which give the voting power, not by delegator shares
+	sort.SliceStable(validators, func(i, j int) bool {
+		return validators[i].Tokens.GT(validators[j].Tokens)
+	})
+
+	// The last active validator is the last bonded one when the set is not full
+	cutoff := sdkmath.ZeroInt()
+	if len(validators) > 0 {
+		last := len(validators)
+		if maxValidators > 0 && maxValidators < last {
+			last = maxValidators
+		}
+		cutoff = validators[last-1].Tokens
+	}
+	ActiveSetCutoffTokensGauge.WithLabelValues(collector.chainID, baseDenom.Display).Set(toDisplay(cutoff, baseDenom.Exponent))
+
+	if valAddress == "" {
+		return nil
+	}
+
+	totalBonded := sdkmath.ZeroInt()
+	var rank int
+	tokens := sdkmath.ZeroInt()
+	for index, validator := range validators {
+		totalBonded = totalBonded.Add(validator.Tokens)
+		if validator.OperatorAddress == valAddress {
+			rank = index + 1
+			tokens = validator.Tokens
+		}
+	}
+
+	// An unbonded validator is not in the bonded set, its tokens are queried on their own
+	if rank == 0 {
+		validatorCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+
+		stakingClient := stakingtypes.NewQueryClient(collector.grpcConn)
+		validatorRes, err := stakingClient.Validator(validatorCtx, &stakingtypes.QueryValidatorRequest{ValidatorAddr: valAddress})
+		if err != nil {
+			ErrorGauge.WithLabelValues("cosmos_staking_validator_cutoff_gap_tokens").Inc()
+			log.Print(err)
+			return err
+		}
+		tokens = validatorRes.Validator.Tokens
+	}
+
+	var share float64
+	if rank > 0 && totalBonded.IsPositive() {
+		share = toDisplay(tokens, 0) / toDisplay(totalBonded, 0)
+	}
+
+	moniker := collector.validatorMoniker()
+	withLabelValues(ctx, ValidatorBondedRankGauge, valAddress, collector.chainID, moniker).Set(float64(rank))
+	withLabelValues(ctx, ValidatorCutoffGapGauge, valAddress, collector.chainID, baseDenom.Display, moniker).Set(toDisplay(tokens.Sub(cutoff), baseDenom.Exponent))
+	withLabelValues(ctx, ValidatorVotingPowerShareGauge, valAddress, collector.chainID, moniker).Set(share)
+	return nil
+}
@@ -0,0 +1,81 @@
+package collector
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+func TestCollectActiveSet(t *testing.T) {
+	full := []stakingtypes.Validator{
+		testValidator("val1", stakingtypes.Bonded, 500_000_000),
+		testValidator("val2", stakingtypes.Bonded, 400_000_000),
+		testValidator("val3", stakingtypes.Bonded, 300_000_000),
+		testValidator("val4", stakingtypes.Unbonded, 100_000_000),
+	}
+	notFull := []stakingtypes.Validator{
+		testValidator("val2", stakingtypes.Bonded, 400_000_000),
+		testValidator("val1", stakingtypes.Bonded, 500_000_000),
+	}
+
+	tests := []struct {
+		name       string
+		validators []stakingtypes.Validator
+		valAddress string
+		wantCutoff float64
+		wantRank   float64
+		wantGap    float64
+		wantShare  float64
+	}{
+		{"bonded validator of a full set", full, "val2", 300, 2, 100, 400.0 / 1200},
+		{"last validator of a full set", full, "val3", 300, 3, 0, 300.0 / 1200},
+		{"validator of a set which is not full", notFull, "val2", 400, 2, 0, 400.0 / 900},
+		{"unbonded validator", full, "val4", 300, 0, -200, 0},
+		{"no monitored validator", full, "", 300, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := newTestConn(t, func(server *grpc.Server) {
+				stakingtypes.RegisterQueryServer(server, &fakeStaking{
+					params:     stakingtypes.Params{MaxValidators: 3},
+					validators: tt.validators,
+				})
+			})
+			collector := newTestCollector(conn, tt.valAddress)
+			defer DeleteChainSeries(collector.chainID)
+
+			if err := collector.CollectActiveSet(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			if got, _ := gaugeValue(t, MaxValidatorsGauge, prometheus.Labels{"chain_id": "test-1"}); got != 3 {
+				t.Errorf("max validators = %v, want 3", got)
+			}
+			if got, _ := gaugeValue(t, ActiveSetCutoffTokensGauge, prometheus.Labels{"chain_id": "test-1", "denom": "atom"}); got != tt.wantCutoff {
+				t.Errorf("cutoff = %v, want %v", got, tt.wantCutoff)
+			}
+
+			validator := prometheus.Labels{"chain_id": "test-1", "validator_address": tt.valAddress}
+			rank, found := gaugeValue(t, ValidatorBondedRankGauge, validator)
+			if tt.valAddress == "" {
+				if found {
+					t.Error("the rank was exported without a monitored validator")
+				}
+				return
+			}
+			if rank != tt.wantRank {
+				t.Errorf("rank = %v, want %v", rank, tt.wantRank)
+			}
+			if got, _ := gaugeValue(t, ValidatorCutoffGapGauge, validator); got != tt.wantGap {
+				t.Errorf("cutoff gap = %v, want %v", got, tt.wantGap)
+			}
+			if got, _ := gaugeValue(t, ValidatorVotingPowerShareGauge, validator); math.Abs(got-tt.wantShare) > 1e-9 {
+				t.Errorf("voting power share = %v, want %v", got, tt.wantShare)
+			}
+		})
+	}
+}
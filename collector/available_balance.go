@@ -5,50 +5,65 @@ import (
 	"log"
 	"math"
 	"strconv"
-	"sync"
 
 	querytypes "github.com/cosmos/cosmos-sdk/types/query"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-func (collector *CosmosSDKCollector) CollectAvailableBalance() {
-	var wg sync.WaitGroup
-	for _, address := range collector.accAddresses {
-		wg.Add(1)
-		go func(address string) {
-			defer wg.Done()
-			bankClient := banktypes.NewQueryClient(collector.grpcConn)
-			bankRes, err := bankClient.AllBalances(
-				context.Background(),
-				&banktypes.QueryAllBalancesRequest{
-					Address: address,
-					Pagination: &querytypes.PageRequest{
-						Limit: 1000,
-					},
-				},
-			)
-			if err != nil {
-				ErrorGauge.WithLabelValues("tendermint_available_balance").Inc()
-				log.Print(err)
-				return
-			}
-
-			for _, balance := range bankRes.Balances {
-				baseDenom, found := collector.denomMetadata[balance.Denom]
-				if !found {
-					log.Print("No denom infos")
-					continue
-				}
-
-				var balanceFromBaseToDisPlay float64
-				if value, err := strconv.ParseFloat(balance.Amount.String(), 64); err != nil {
-					balanceFromBaseToDisPlay = 0
-				} else {
-					balanceFromBaseToDisPlay = value / math.Pow10(int(baseDenom.Exponent))
-				}
-				AvailableBalanceGauge.WithLabelValues(collector.chainID, address, baseDenom.Display).Set(balanceFromBaseToDisPlay)
-			}
-		}(address)
+func (collector *CosmosSDKCollector) CollectAvailableBalance(ctx context.Context) error {
+	defer collector.balanceHistory.prune(collector.delegatorAddresses())
+
+	return collector.forEachAddress(ctx, func(ctx context.Context, address string) error {
+		balances, err := collector.collectBalance(ctx, address, AvailableBalanceGauge)
+		if err != nil {
+			return err
+		}
+		collector.collectBalanceRunway(ctx, address, balances)
+		return nil
+	})
+}
+
+// collectBalance sets the available balances of address on gauge and
+// returns them by display denom
+func (collector *CosmosSDKCollector) collectBalance(ctx context.Context, address string, gauge *prometheus.GaugeVec) (map[string]float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	bankClient := banktypes.NewQueryClient(collector.grpcConn)
+	bankRes, err := bankClient.AllBalances(
+		ctx,
+		&banktypes.QueryAllBalancesRequest{
+			Address: address,
+			Pagination: &querytypes.PageRequest{
+				Limit: 1000,
+			},
+		},
+	)
+	if err != nil {
+		ErrorGauge.WithLabelValues("tendermint_available_balance").Inc()
+		log.Print(err)
+		return nil, err
+	}
+
+	balances := make(map[string]float64, len(bankRes.Balances))
+	labels := collector.addressLabels(address)
+	for _, balance := range bankRes.Balances {
+		baseDenom, found := collector.denomMetadata[balance.Denom]
+		if !found {
+			ErrorGauge.WithLabelValues("tendermint_available_balance").Inc()
+			log.Printf("No denom infos for %s", balance.Denom)
+			continue
+		}
+
+		var balanceFromBaseToDisPlay float64
+		if value, err := strconv.ParseFloat(balance.Amount.String(), 64); err != nil {
+			balanceFromBaseToDisPlay = 0
+		} else {
+			balanceFromBaseToDisPlay = value / math.Pow10(int(baseDenom.Exponent))
+		}
+		withLabelValues(ctx, gauge, collector.chainID, address, baseDenom.Display, labels.Alias, labels.Owner, labels.Team).Set(balanceFromBaseToDisPlay)
+		balances[baseDenom.Display] = balanceFromBaseToDisPlay
 	}
-	wg.Wait()
+	return balances, nil
 }
@@ -0,0 +1,180 @@
+package collector
+
+import (
+	"context"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	types "github.com/forbole/cosmos-exporter/types"
+)
+
+// balanceHistory keeps the balances of the monitored addresses seen during
+// the balances window, to estimate how fast they are spent
+type balanceHistory struct {
+	mu      sync.Mutex
+	samples map[balanceKey][]balanceSample
+}
+
+type balanceKey struct {
+	address string
+	denom   string
+}
+
+type balanceSample struct {
+	at     time.Time
+	amount float64
+}
+
+// record adds a balance sample and returns the amount spent per second over
+// the window, top-ups excluded, false until two samples were recorded
+func (h *balanceHistory) record(address, denom string, amount float64, now time.Time, window time.Duration) (float64, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.samples == nil {
+		h.samples = make(map[balanceKey][]balanceSample)
+	}
+	key := balanceKey{address: address, denom: denom}
+	samples := append(h.samples[key], balanceSample{at: now, amount: amount})
+	for len(samples) > 2 && now.Sub(samples[0].at) > window {
+		samples = samples[1:]
+	}
+	h.samples[key] = samples
+
+	elapsed := samples[len(samples)-1].at.Sub(samples[0].at).Seconds()
+	if elapsed <= 0 {
+		return 0, false
+	}
+	var spent float64
+	for i := 1; i < len(samples); i++ {
+		if drop := samples[i-1].amount - samples[i].amount; drop > 0 {
+			spent += drop
+		}
+	}
+	return spent / elapsed, true
+}
+
+// denoms returns the denoms of address which have samples
+func (h *balanceHistory) denoms(address string) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var denoms []string
+	for key := range h.samples {
+		if key.address == address {
+			denoms = append(denoms, key.denom)
+		}
+	}
+	return denoms
+}
+
+// prune forgets the addresses which are no longer monitored
+func (h *balanceHistory) prune(addresses []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	monitored := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		monitored[address] = true
+	}
+	for key := range h.samples {
+		if !monitored[key.address] {
+			delete(h.samples, key)
+		}
+	}
+}
+
+// SetBalances replaces the balances window and thresholds config
+func (c *CosmosSDKCollector) SetBalances(balances types.Balances) {
+	balances = balances.WithDefaults()
+	balances.Thresholds = c.validThresholds(balances.Thresholds)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.balances = balances
+}
+
+// validThresholds returns the thresholds whose denom is the display denom of
+// a known denom, with the case of its metadata as config keys are lower-cased.
+// The other thresholds are logged and dropped, as their balance is unknown.
+func (c *CosmosSDKCollector) validThresholds(thresholds map[string]map[string]float64) map[string]map[string]float64 {
+	displays := make(map[string]string, len(c.denomMetadata))
+	for _, metadata := range c.denomMetadata {
+		displays[strings.ToLower(metadata.Display)] = metadata.Display
+	}
+
+	valid := make(map[string]map[string]float64, len(thresholds))
+	for address, denoms := range thresholds {
+		for denom, threshold := range denoms {
+			display, found := displays[strings.ToLower(denom)]
+			if !found {
+				log.Printf("Ignoring the balance threshold of %s in %s, it is not the display denom of a known denom", address, denom)
+				continue
+			}
+			if valid[address] == nil {
+				valid[address] = make(map[string]float64)
+			}
+			valid[address][display] = threshold
+		}
+	}
+	return valid
+}
+
+func (c *CosmosSDKCollector) balancesConfig() types.Balances {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.balances
+}
+
+// collectBalanceRunway exports the thresholds, spend rate and time until
+// empty of the balances of address, given in display denom
+func (collector *CosmosSDKCollector) collectBalanceRunway(ctx context.Context, address string, balances map[string]float64) {
+	cfg := collector.balancesConfig()
+	labels := collector.addressLabels(address)
+
+	// Denoms spent entirely are not returned anymore, their last sample is 0
+	amounts := make(map[string]float64, len(balances))
+	for _, denom := range collector.balanceHistory.denoms(address) {
+		amounts[denom] = 0
+	}
+	for denom, amount := range balances {
+		amounts[denom] = amount
+	}
+	for denom, threshold := range cfg.Thresholds[address] {
+		amount := amounts[denom]
+		amounts[denom] = amount
+
+		var below float64
+		if amount < threshold {
+			below = 1
+		}
+		withLabelValues(ctx, BalanceThresholdGauge, collector.chainID, address, denom, labels.Alias, labels.Owner, labels.Team).Set(threshold)
+		withLabelValues(ctx, BalanceBelowThresholdGauge, collector.chainID, address, denom, labels.Alias, labels.Owner, labels.Team).Set(below)
+	}
+
+	now := time.Now()
+	for denom, amount := range amounts {
+		rate, ok := collector.balanceHistory.record(address, denom, amount, now, cfg.Window)
+		if !ok {
+			continue
+		}
+		withLabelValues(ctx, BalanceSpendRateGauge, collector.chainID, address, denom, labels.Alias, labels.Owner, labels.Team).Set(rate)
+		withLabelValues(ctx, BalanceDepletionGauge, collector.chainID, address, denom, labels.Alias, labels.Owner, labels.Team).Set(depletionSeconds(amount, rate))
+	}
+}
+
+// depletionSeconds returns the time until amount is spent at rate, 0 once
+// empty and +Inf when not spending
+func depletionSeconds(amount, rate float64) float64 {
+	switch {
+	case amount <= 0:
+		return 0
+	case rate <= 0:
+		return math.Inf(1)
+	default:
+		return amount / rate
+	}
+}
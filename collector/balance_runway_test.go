@@ -0,0 +1,157 @@
+package collector
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	types "github.com/forbole/cosmos-exporter/types"
+)
+
+func TestBalanceHistoryRecord(t *testing.T) {
+	start := time.Unix(1000, 0)
+	window := 100 * time.Second
+	// step records amount after elapsed seconds
+	type step struct {
+		elapsed  int64
+		amount   float64
+		wantRate float64
+		wantOk   bool
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"first sample", []step{
+			{0, 100, 0, false},
+		}},
+		{"samples at the same time", []step{
+			{0, 100, 0, false},
+			{0, 90, 0, false},
+		}},
+		{"spending", []step{
+			{0, 100, 0, false},
+			{10, 90, 1, true},
+			{20, 70, 1.5, true},
+		}},
+		{"top-ups are excluded", []step{
+			{0, 100, 0, false},
+			{10, 90, 1, true},
+			{20, 150, 0.5, true},
+			{30, 140, 20.0 / 30, true},
+		}},
+		{"samples older than the window are trimmed", []step{
+			{0, 100, 0, false},
+			{50, 50, 1, true},
+			{150, 40, 0.1, true},
+			{200, 30, 0.2, true},
+		}},
+		{"two samples are kept beyond the window", []step{
+			{0, 100, 0, false},
+			{500, 50, 0.1, true},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var history balanceHistory
+			for i, s := range tt.steps {
+				now := start.Add(time.Duration(s.elapsed) * time.Second)
+				rate, ok := history.record("addr", "atom", s.amount, now, window)
+				if ok != s.wantOk {
+					t.Fatalf("step %d: ok = %v, want %v", i, ok, s.wantOk)
+				}
+				if math.Abs(rate-s.wantRate) > 1e-9 {
+					t.Errorf("step %d: rate = %v, want %v", i, rate, s.wantRate)
+				}
+			}
+		})
+	}
+}
+
+func TestBalanceHistoryDenomsAndPrune(t *testing.T) {
+	var history balanceHistory
+	now := time.Unix(1000, 0)
+	history.record("addr1", "atom", 1, now, time.Hour)
+	history.record("addr1", "osmo", 1, now, time.Hour)
+	history.record("addr2", "atom", 1, now, time.Hour)
+
+	denoms := history.denoms("addr1")
+	sort.Strings(denoms)
+	if want := []string{"atom", "osmo"}; !reflect.DeepEqual(denoms, want) {
+		t.Errorf("denoms(addr1) = %v, want %v", denoms, want)
+	}
+
+	history.prune([]string{"addr2"})
+	if denoms := history.denoms("addr1"); len(denoms) != 0 {
+		t.Errorf("denoms of a pruned address = %v, want none", denoms)
+	}
+	if denoms := history.denoms("addr2"); !reflect.DeepEqual(denoms, []string{"atom"}) {
+		t.Errorf("denoms(addr2) = %v, want [atom]", denoms)
+	}
+}
+
+func TestDepletionSeconds(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount float64
+		rate   float64
+		want   float64
+	}{
+		{"empty", 0, 1, 0},
+		{"overdrawn", -1, 1, 0},
+		{"empty and not spending", 0, 0, 0},
+		{"not spending", 100, 0, math.Inf(1)},
+		{"spending", 100, 4, 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := depletionSeconds(tt.amount, tt.rate); got != tt.want {
+				t.Errorf("depletionSeconds(%v, %v) = %v, want %v", tt.amount, tt.rate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidThresholds(t *testing.T) {
+	collector := &CosmosSDKCollector{
+		denomMetadata: map[string]types.DenomMetadata{
+			"uatom": types.NewDenomMetadata("uatom", "ATOM", 6),
+			"uosmo": types.NewDenomMetadata("uosmo", "osmo", 6),
+		},
+	}
+	tests := []struct {
+		name       string
+		thresholds map[string]map[string]float64
+		want       map[string]map[string]float64
+	}{
+		{
+			name:       "display denom in the case of its metadata",
+			thresholds: map[string]map[string]float64{"addr": {"ATOM": 10}},
+			want:       map[string]map[string]float64{"addr": {"ATOM": 10}},
+		},
+		{
+			name:       "lower-cased display denom",
+			thresholds: map[string]map[string]float64{"addr": {"atom": 10, "osmo": 5}},
+			want:       map[string]map[string]float64{"addr": {"ATOM": 10, "osmo": 5}},
+		},
+		{
+			name:       "base denom is dropped",
+			thresholds: map[string]map[string]float64{"addr": {"uatom": 10, "osmo": 5}},
+			want:       map[string]map[string]float64{"addr": {"osmo": 5}},
+		},
+		{
+			name:       "unknown denom is dropped",
+			thresholds: map[string]map[string]float64{"addr1": {"juno": 10}, "addr2": {"atom": 1}},
+			want:       map[string]map[string]float64{"addr2": {"ATOM": 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collector.validThresholds(tt.thresholds); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validThresholds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
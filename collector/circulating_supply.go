@@ -7,56 +7,65 @@ import (
 	"strconv"
 
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	types "github.com/forbole/cosmos-exporter/types"
 )
 
-func (collector *CosmosSDKCollector) CollectCirculatingSupply() {
+func (collector *CosmosSDKCollector) CollectCirculatingSupply(ctx context.Context) error {
 	if collector.sdkVersion == SDKVersionLegacy {
-		collector.collectCirculatingSupplyLegacy()
-	} else {
-		collector.collectCirculatingSupplyCurrent()
+		return collector.collectCirculatingSupplyLegacy(ctx)
 	}
+	return collector.collectCirculatingSupplyCurrent(ctx)
 }
 
 // Implementation for pre-v0.50.x chains
-func (collector *CosmosSDKCollector) collectCirculatingSupplyLegacy() {
+func (collector *CosmosSDKCollector) collectCirculatingSupplyLegacy(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
 	bankClient := banktypes.NewQueryClient(collector.grpcConn)
 	bankRes, err := bankClient.SupplyOf(
-		context.Background(),
+		ctx,
 		&banktypes.QuerySupplyOfRequest{Denom: collector.defaultMintDenom},
 	)
 	if err != nil {
 		ErrorGauge.WithLabelValues("tendermint_circulating_supply").Inc()
 		log.Print(err)
-		return
+		return err
 	}
 
 	baseDenom, found := collector.denomMetadata[collector.defaultMintDenom]
 	if !found {
+		ErrorGauge.WithLabelValues("tendermint_circulating_supply").Inc()
 		log.Print("No denom infos")
-		return
+		return &types.DenomNotFound{}
 	}
 	SupplyFromBaseToDisplay := float64(bankRes.Amount.Amount.Int64()) / math.Pow10(int(baseDenom.Exponent))
 
 	CirculatingSupply.WithLabelValues(collector.chainID).Set(SupplyFromBaseToDisplay)
+	return nil
 }
 
 // Implementation for v0.50.x chains
-func (collector *CosmosSDKCollector) collectCirculatingSupplyCurrent() {
+func (collector *CosmosSDKCollector) collectCirculatingSupplyCurrent(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
 	bankClient := banktypes.NewQueryClient(collector.grpcConn)
 	bankRes, err := bankClient.SupplyOf(
-		context.Background(),
+		ctx,
 		&banktypes.QuerySupplyOfRequest{Denom: collector.defaultMintDenom},
 	)
 	if err != nil {
 		ErrorGauge.WithLabelValues("tendermint_circulating_supply").Inc()
 		log.Print(err)
-		return
+		return err
 	}
 
 	baseDenom, found := collector.denomMetadata[collector.defaultMintDenom]
 	if !found {
+		ErrorGauge.WithLabelValues("tendermint_circulating_supply").Inc()
 		log.Print("No denom infos")
-		return
+		return &types.DenomNotFound{}
 	}
 
 	// Use string conversion to handle large token amounts safely
@@ -64,10 +73,11 @@ func (collector *CosmosSDKCollector) collectCirculatingSupplyCurrent() {
 	if err != nil {
 		ErrorGauge.WithLabelValues("tendermint_circulating_supply").Inc()
 		log.Print(err)
-		return
+		return err
 	}
 
 	SupplyFromBaseToDisplay := supplyValue / math.Pow10(int(baseDenom.Exponent))
 
 	CirculatingSupply.WithLabelValues(collector.chainID).Set(SupplyFromBaseToDisplay)
+	return nil
 }
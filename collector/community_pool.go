@@ -0,0 +1,35 @@
+package collector
+
+import (
+	"context"
+	"log"
+
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+func (collector *CosmosSDKCollector) CollectCommunityPool(ctx context.Context) error {
+	queryCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	distributionClient := distributiontypes.NewQueryClient(collector.grpcConn)
+	distributionRes, err := distributionClient.CommunityPool(
+		queryCtx,
+		&distributiontypes.QueryCommunityPoolRequest{},
+	)
+	if err != nil {
+		ErrorGauge.WithLabelValues("cosmos_distribution_community_pool_tokens").Inc()
+		log.Print(err)
+		return err
+	}
+
+	for _, coin := range distributionRes.Pool {
+		denom, amount, found := collector.decCoinToDisplay(coin.Denom, coin.Amount)
+		if !found {
+			ErrorGauge.WithLabelValues("cosmos_distribution_community_pool_tokens").Inc()
+			log.Printf("No denom infos for %s", coin.Denom)
+			continue
+		}
+		withLabelValues(ctx, CommunityPoolGauge, collector.chainID, denom).Set(amount)
+	}
+	return nil
+}
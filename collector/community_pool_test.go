@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+func TestCollectCommunityPool(t *testing.T) {
+	tests := []struct {
+		name string
+		pool sdk.DecCoins
+		want map[string]float64
+	}{
+		{"empty pool", nil, map[string]float64{}},
+		{
+			name: "pool in display denom",
+			pool: sdk.NewDecCoins(sdk.NewDecCoinFromDec("uatom", sdkmath.LegacyMustNewDecFromStr("2500000.7"))),
+			want: map[string]float64{"atom": 2.5000007},
+		},
+		{
+			name: "denom without metadata is skipped",
+			pool: sdk.NewDecCoins(
+				sdk.NewDecCoinFromDec("uatom", sdkmath.LegacyNewDec(1_000_000)),
+				sdk.NewDecCoinFromDec("uosmo", sdkmath.LegacyNewDec(7)),
+			),
+			want: map[string]float64{"atom": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := newTestConn(t, func(server *grpc.Server) {
+				distributiontypes.RegisterQueryServer(server, &fakeDistribution{pool: tt.pool})
+			})
+			collector := newTestCollector(conn, "")
+			defer DeleteChainSeries(collector.chainID)
+
+			if err := collector.CollectCommunityPool(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			got := make(map[string]float64)
+			for _, denom := range labelValues(t, CommunityPoolGauge, "denom") {
+				got[denom], _ = gaugeValue(t, CommunityPoolGauge, prometheus.Labels{"chain_id": "test-1", "denom": denom})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("community pool = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,17 +7,21 @@ import (
 	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 )
 
-func (collector *CosmosSDKCollector) CollectCommunityTax() {
+func (collector *CosmosSDKCollector) CollectCommunityTax(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
 	distributionClient := distributiontypes.NewQueryClient(collector.grpcConn)
 	distributionRes, err := distributionClient.Params(
-		context.Background(),
+		ctx,
 		&distributiontypes.QueryParamsRequest{},
 	)
 	if err != nil {
 		ErrorGauge.WithLabelValues("tendermint_community_tax_rate").Inc()
 		log.Print(err)
-		return
+		return err
 	}
 
 	CommunityTax.WithLabelValues(collector.chainID).Set(distributionRes.Params.CommunityTax.MustFloat64())
+	return nil
 }
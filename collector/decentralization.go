@@ -0,0 +1,105 @@
+package collector
+
+import (
+	"sort"
+	"strconv"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// topShares are the numbers of largest validators whose cumulative share of
+// the bonded tokens is exported
+var topShares = []int{5, 10, 20}
+
+// nakamotoThresholds are the shares of the bonded tokens needed to halt (1/3)
+// or to control (2/3) the chain
+var nakamotoThresholds = []struct {
+	label string
+	share float64
+}{
+	{"1/3", 1.0 / 3},
+	{"2/3", 2.0 / 3},
+}
+
+// exportDecentralization derives the chain level concentration metrics and
+// the validator counts from the validator set
+func (collector *CosmosSDKCollector) exportDecentralization(validators []stakingtypes.Validator) {
+	counts := map[string]float64{"bonded": 0, "unbonding": 0, "unbonded": 0}
+	var jailed float64
+	var bonded []float64
+	var total float64
+	for _, validator := range validators {
+		switch validator.GetStatus() {
+		case stakingtypes.Bonded:
+			counts["bonded"]++
+			tokens := toDisplay(validator.Tokens, 0)
+			bonded = append(bonded, tokens)
+			total += tokens
+		case stakingtypes.Unbonding:
+			counts["unbonding"]++
+		case stakingtypes.Unbonded:
+			counts["unbonded"]++
+		}
+		if validator.Jailed {
+			jailed++
+		}
+	}
+	for status, count := range counts {
+		ValidatorsCountGauge.WithLabelValues(collector.chainID, status).Set(count)
+	}
+	JailedValidatorsCountGauge.WithLabelValues(collector.chainID).Set(jailed)
+
+	if total == 0 {
+		return
+	}
+
+	// Largest first
+	sort.Sort(sort.Reverse(sort.Float64Slice(bonded)))
+
+	for _, threshold := range nakamotoThresholds {
+		NakamotoCoefficientGauge.WithLabelValues(collector.chainID, threshold.label).Set(float64(nakamotoCoefficient(bonded, total, threshold.share)))
+	}
+
+	for _, n := range topShares {
+		TopValidatorsShareGauge.WithLabelValues(collector.chainID, strconv.Itoa(n)).Set(topShare(bonded, total, n))
+	}
+
+	VotingPowerGiniGauge.WithLabelValues(collector.chainID).Set(gini(bonded, total))
+}
+
+// nakamotoCoefficient returns the smallest number of values sorted in
+// descending order whose sum is more than share of total
+func nakamotoCoefficient(values []float64, total float64, share float64) int {
+	var cumulative float64
+	coefficient := 0
+	for _, value := range values {
+		cumulative += value
+		coefficient++
+		if cumulative > share*total {
+			break
+		}
+	}
+	return coefficient
+}
+
+// topShare returns the share of total held by the n largest of values sorted
+// in descending order
+func topShare(values []float64, total float64, n int) float64 {
+	var cumulative float64
+	for i := 0; i < n && i < len(values); i++ {
+		cumulative += values[i]
+	}
+	return cumulative / total
+}
+
+// gini returns the Gini coefficient of values sorted in descending order
+// whose sum is total, 0 when evenly spread and close to 1 when concentrated
+func gini(values []float64, total float64) float64 {
+	n := float64(len(values))
+	var weighted float64
+	for i, value := range values {
+		// Rank in ascending order, starting at 1
+		weighted += (n - float64(i)) * value
+	}
+	return 2*weighted/(n*total) - (n+1)/n
+}
@@ -0,0 +1,76 @@
+package collector
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGini(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"single validator", []float64{10}, 0},
+		{"evenly spread", []float64{5, 5, 5, 5}, 0},
+		{"fully concentrated", []float64{100, 0, 0, 0}, 0.75},
+		{"uneven", []float64{3, 1}, 0.25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var total float64
+			for _, value := range tt.values {
+				total += value
+			}
+			if got := gini(tt.values, total); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("gini(%v) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNakamotoCoefficient(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		share  float64
+		want   int
+	}{
+		{"largest halts", []float64{40, 30, 20, 10}, 1.0 / 3, 1},
+		{"two control", []float64{40, 30, 20, 10}, 2.0 / 3, 2},
+		{"evenly spread halt", []float64{25, 25, 25, 25}, 1.0 / 3, 2},
+		{"evenly spread control", []float64{25, 25, 25, 25}, 2.0 / 3, 3},
+		{"exactly a third does not halt", []float64{1, 1, 1}, 1.0 / 3, 2},
+		{"exactly two thirds do not control", []float64{1, 1, 1}, 2.0 / 3, 3},
+		{"single validator", []float64{10}, 2.0 / 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var total float64
+			for _, value := range tt.values {
+				total += value
+			}
+			if got := nakamotoCoefficient(tt.values, total, tt.share); got != tt.want {
+				t.Errorf("nakamotoCoefficient(%v, %v) = %d, want %d", tt.values, tt.share, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTopShare(t *testing.T) {
+	values := []float64{40, 30, 20, 10}
+	tests := []struct {
+		n    int
+		want float64
+	}{
+		{1, 0.4},
+		{2, 0.7},
+		{4, 1},
+		{10, 1},
+	}
+	for _, tt := range tests {
+		if got := topShare(values, 100, tt.n); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("topShare(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
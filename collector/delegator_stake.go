@@ -5,45 +5,50 @@ import (
 	"log"
 	"math"
 	"strconv"
-	"sync"
 
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	types "github.com/forbole/cosmos-exporter/types"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-func (collector *CosmosSDKCollector) CollecDelegatorStake() {
-	var wg sync.WaitGroup
-	for _, address := range collector.accAddresses {
-		wg.Add(1)
-		go func(address string) {
-			defer wg.Done()
-			stakingClient := stakingtypes.NewQueryClient(collector.grpcConn)
-			stakingRes, err := stakingClient.DelegatorDelegations(
-				context.Background(),
-				&stakingtypes.QueryDelegatorDelegationsRequest{DelegatorAddr: address},
-			)
-			if err != nil {
-				ErrorGauge.WithLabelValues("tendermint_staking_total").Inc()
-				log.Print(err)
-				return
-			}
+func (collector *CosmosSDKCollector) CollecDelegatorStake(ctx context.Context) error {
+	return collector.forEachAddress(ctx, func(ctx context.Context, address string) error {
+		return collector.collectDelegations(ctx, address, DelegatorStakeGauge)
+	})
+}
+
+// collectDelegations sets the stake of address to each of its validators on gauge
+func (collector *CosmosSDKCollector) collectDelegations(ctx context.Context, address string, gauge *prometheus.GaugeVec) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	stakingClient := stakingtypes.NewQueryClient(collector.grpcConn)
+	stakingRes, err := stakingClient.DelegatorDelegations(
+		ctx,
+		&stakingtypes.QueryDelegatorDelegationsRequest{DelegatorAddr: address},
+	)
+	if err != nil {
+		ErrorGauge.WithLabelValues("tendermint_staking_total").Inc()
+		log.Print(err)
+		return err
+	}
 
-			for _, delegation := range stakingRes.DelegationResponses {
-				baseDenom, found := collector.denomMetadata[collector.defaultBondDenom]
-				if !found {
-					ErrorGauge.WithLabelValues("tendermint_staking_total").Inc()
-					log.Print("No denom infos")
-					return
-				}
+	labels := collector.addressLabels(address)
+	for _, delegation := range stakingRes.DelegationResponses {
+		baseDenom, found := collector.denomMetadata[collector.defaultBondDenom]
+		if !found {
+			ErrorGauge.WithLabelValues("tendermint_staking_total").Inc()
+			log.Print("No denom infos")
+			return &types.DenomNotFound{}
+		}
 
-				var delegationFromBaseToDisplay float64
-				if value, err := strconv.ParseFloat(delegation.Balance.Amount.String(), 64); err != nil {
-					delegationFromBaseToDisplay = 0
-				} else {
-					delegationFromBaseToDisplay = value / math.Pow10(int(baseDenom.Exponent))
-				}
-				DelegatorStakeGauge.WithLabelValues(address, delegation.Delegation.ValidatorAddress, collector.chainID, baseDenom.Display).Set(delegationFromBaseToDisplay)
-			}
-		}(address)
+		var delegationFromBaseToDisplay float64
+		if value, err := strconv.ParseFloat(delegation.Balance.Amount.String(), 64); err != nil {
+			delegationFromBaseToDisplay = 0
+		} else {
+			delegationFromBaseToDisplay = value / math.Pow10(int(baseDenom.Exponent))
+		}
+		withLabelValues(ctx, gauge, address, delegation.Delegation.ValidatorAddress, collector.chainID, baseDenom.Display, labels.Alias, labels.Owner, labels.Team).Set(delegationFromBaseToDisplay)
 	}
-	wg.Wait()
+	return nil
 }
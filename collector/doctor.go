@@ -0,0 +1,270 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	querytypes "github.com/cosmos/cosmos-sdk/types/query"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	v1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	types "github.com/forbole/cosmos-exporter/types"
+)
+
+// Check is the result of running a single query used by the collectors,
+// together with the metrics, by legacy name, that stay empty when it fails
+type Check struct {
+	Module  string
+	Query   string
+	Target  string
+	Metrics []string
+	// Fallback is the module/query the collectors use instead when this one
+	// fails, in which case the metrics are still populated
+	Fallback string
+	Latency  time.Duration
+	Err      error
+}
+
+// Failed returns true if the query returned an error
+func (c Check) Failed() bool {
+	return c.Err != nil
+}
+
+// ChainID returns the chain id used as the chain_id label
+func (collector *CosmosSDKCollector) ChainID() string {
+	return collector.chainID
+}
+
+// SDKVersion returns the detected Cosmos SDK version family
+func (collector *CosmosSDKCollector) SDKVersion() SDKVersion {
+	return collector.sdkVersion
+}
+
+// BondDenom returns the denom used for staking metrics
+func (collector *CosmosSDKCollector) BondDenom() string {
+	return collector.defaultBondDenom
+}
+
+// MintDenom returns the denom used for rewards and supply metrics
+func (collector *CosmosSDKCollector) MintDenom() string {
+	return collector.defaultMintDenom
+}
+
+// DenomMetadata returns the resolved denom metadata keyed by base denom
+func (collector *CosmosSDKCollector) DenomMetadata() map[string]types.DenomMetadata {
+	return collector.denomMetadata
+}
+
+// Diagnose runs every query the collectors depend on once and reports
+// whether it succeeded, how long it took and which metrics depend on it
+func (collector *CosmosSDKCollector) Diagnose(ctx context.Context) []Check {
+	valAddress := collector.validatorAddress()
+	var checks []Check
+
+	runWithFallback := func(module, query, target string, metrics []string, fallback string, fn func(ctx context.Context) error) {
+		ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+
+		start := time.Now()
+		err := fn(ctx)
+		checks = append(checks, Check{
+			Module:   module,
+			Query:    query,
+			Target:   target,
+			Metrics:  metrics,
+			Fallback: fallback,
+			Latency:  time.Since(start),
+			Err:      err,
+		})
+	}
+	run := func(module, query, target string, metrics []string, fn func(ctx context.Context) error) {
+		runWithFallback(module, query, target, metrics, "", fn)
+	}
+
+	authClient := authtypes.NewQueryClient(collector.grpcConn)
+	bankClient := banktypes.NewQueryClient(collector.grpcConn)
+	distributionClient := distributiontypes.NewQueryClient(collector.grpcConn)
+	govClient := v1.NewQueryClient(collector.grpcConn)
+	mintClient := minttypes.NewQueryClient(collector.grpcConn)
+	stakingClient := stakingtypes.NewQueryClient(collector.grpcConn)
+
+	run("cometbft", "Status", collector.rpcClient.Remote(), []string{"chain_id label"}, func(ctx context.Context) error {
+		_, err := nodeStatus(ctx, collector.rpcClient)
+		return err
+	})
+
+	run("cometbft", "Validators", collector.rpcClient.Remote(), []string{"cosmos_staking_validator_cometbft_voting_power", "cosmos_staking_validator_voting_power_mismatch"}, func(ctx context.Context) error {
+		_, err := nodeValidators(ctx, collector.rpcClient)
+		return err
+	})
+
+	run("bank", "DenomsMetadata", "", []string{"all denominated metrics"}, func(ctx context.Context) error {
+		_, err := bankClient.DenomsMetadata(ctx, &banktypes.QueryDenomsMetadataRequest{
+			Pagination: &querytypes.PageRequest{Limit: 1000},
+		})
+		return err
+	})
+
+	run("gov", "Proposals", "", []string{"tendermint_active_proposals_total", "tendermint_active_proposals_vote_status"}, func(ctx context.Context) error {
+		_, err := govClient.Proposals(ctx, &v1.QueryProposalsRequest{ProposalStatus: v1.StatusVotingPeriod})
+		return err
+	})
+
+	for _, address := range collector.delegatorAddresses() {
+		run("bank", "AllBalances", address, []string{"tendermint_available_balance"}, func(ctx context.Context) error {
+			_, err := bankClient.AllBalances(ctx, &banktypes.QueryAllBalancesRequest{
+				Address:    address,
+				Pagination: &querytypes.PageRequest{Limit: 1000},
+			})
+			return err
+		})
+		run("distribution", "DelegationTotalRewards", address, []string{"tendermint_staking_reward_total"}, func(ctx context.Context) error {
+			_, err := distributionClient.DelegationTotalRewards(ctx, &distributiontypes.QueryDelegationTotalRewardsRequest{DelegatorAddress: address})
+			return err
+		})
+		run("staking", "DelegatorDelegations", address, []string{"tendermint_staking_total"}, func(ctx context.Context) error {
+			_, err := stakingClient.DelegatorDelegations(ctx, &stakingtypes.QueryDelegatorDelegationsRequest{DelegatorAddr: address})
+			return err
+		})
+		run("auth", "Account", address, []string{"cosmos_auth_vesting_*", "cosmos_auth_account_*"}, func(ctx context.Context) error {
+			_, err := authClient.Account(ctx, &authtypes.QueryAccountRequest{Address: address})
+			return err
+		})
+		run("bank", "SpendableBalances", address, []string{"cosmos_bank_spendable_balance_tokens"}, func(ctx context.Context) error {
+			_, err := bankClient.SpendableBalances(ctx, &banktypes.QuerySpendableBalancesRequest{
+				Address:    address,
+				Pagination: &querytypes.PageRequest{Limit: 1000},
+			})
+			return err
+		})
+	}
+
+	validatorChecks := []struct {
+		module  string
+		query   string
+		metrics []string
+		fn      func(ctx context.Context) error
+	}{
+		{"distribution", "ValidatorCommission", []string{"tendermint_validator_commission_total"}, func(ctx context.Context) error {
+			_, err := distributionClient.ValidatorCommission(ctx, &distributiontypes.QueryValidatorCommissionRequest{ValidatorAddress: valAddress})
+			return err
+		}},
+		{"distribution", "ValidatorOutstandingRewards", []string{"cosmos_distribution_validator_outstanding_rewards_tokens"}, func(ctx context.Context) error {
+			_, err := distributionClient.ValidatorOutstandingRewards(ctx, &distributiontypes.QueryValidatorOutstandingRewardsRequest{ValidatorAddress: valAddress})
+			return err
+		}},
+		{"distribution", "ValidatorSlashes", []string{"cosmos_distribution_validator_slashes", "cosmos_distribution_validator_slashed_ratio", "cosmos_distribution_validator_last_slash_ratio"}, func(ctx context.Context) error {
+			status, err := nodeStatus(ctx, collector.rpcClient)
+			if err != nil {
+				return err
+			}
+			_, err = distributionClient.ValidatorSlashes(ctx, &distributiontypes.QueryValidatorSlashesRequest{
+				ValidatorAddress: valAddress,
+				EndingHeight:     uint64(status.SyncInfo.LatestBlockHeight),
+				Pagination:       &querytypes.PageRequest{Limit: 1},
+			})
+			return err
+		}},
+		{"staking", "Delegation", []string{"cosmos_staking_validator_self_delegation_tokens", "cosmos_staking_validator_self_delegation_near_minimum"}, func(ctx context.Context) error {
+			_, err := collector.selfDelegation(ctx, stakingClient)
+			return err
+		}},
+		{"staking", "ValidatorDelegations", []string{"tendermint_validator_delegators_total", "cosmos_staking_validator_top_delegator_tokens", "cosmos_staking_validator_delegation_inflow_tokens"}, func(ctx context.Context) error {
+			_, err := stakingClient.ValidatorDelegations(ctx, &stakingtypes.QueryValidatorDelegationsRequest{
+				ValidatorAddr: valAddress,
+				Pagination:    &querytypes.PageRequest{CountTotal: true},
+			})
+			return err
+		}},
+		{"staking", "Validator", []string{"tendermint_validator_jailed", "tendermint_validator_commission_rate", "tendermint_validator_voting_power_total", "cosmos_staking_validator_consensus_power"}, func(ctx context.Context) error {
+			_, err := stakingClient.Validator(ctx, &stakingtypes.QueryValidatorRequest{ValidatorAddr: valAddress})
+			return err
+		}},
+	}
+	for _, c := range validatorChecks {
+		if valAddress == "" {
+			checks = append(checks, Check{
+				Module:  c.module,
+				Query:   c.query,
+				Metrics: c.metrics,
+				Err:     fmt.Errorf("validator_address is not configured"),
+			})
+			continue
+		}
+		run(c.module, c.query, valAddress, c.metrics, c.fn)
+	}
+
+	run("staking", "Validators", "", []string{"tendermint_bonded_token", "tendermint_not_bonded_token", "tendermint_validator_voting_power_ranking", "cosmos_staking_active_set_cutoff_tokens", "cosmos_staking_validator_bonded_rank", "cosmos_staking_validator_cutoff_gap_tokens", "cosmos_staking_validator_voting_power_ratio", "cosmos_staking_validators", "cosmos_staking_nakamoto_coefficient", "cosmos_staking_voting_power_gini", "cosmos_staking_set_validator_*"}, func(ctx context.Context) error {
+		_, err := stakingClient.Validators(ctx, &stakingtypes.QueryValidatorsRequest{
+			Pagination: &querytypes.PageRequest{Limit: 1000},
+		})
+		return err
+	})
+
+	run("staking", "Params", "", []string{"tendermint_unbonding_time", "cosmos_staking_max_validators"}, func(ctx context.Context) error {
+		_, err := stakingClient.Params(ctx, &stakingtypes.QueryParamsRequest{})
+		return err
+	})
+
+	run("bank", "SupplyOf", collector.defaultMintDenom, []string{"tendermint_circulating_supply"}, func(ctx context.Context) error {
+		_, err := bankClient.SupplyOf(ctx, &banktypes.QuerySupplyOfRequest{Denom: collector.defaultMintDenom})
+		return err
+	})
+
+	// Only custom mint modules hold the inflation in their params, which the
+	// current SDK collector reads before falling back to the Inflation query
+	if collector.sdkVersion != SDKVersionLegacy {
+		runWithFallback("mint", "Params", "", []string{"tendermint_inflation_rate"}, "mint/Inflation", func(ctx context.Context) error {
+			_, err := mintClient.Params(ctx, &minttypes.QueryParamsRequest{})
+			return err
+		})
+	}
+
+	run("mint", "Inflation", "", []string{"tendermint_inflation_rate"}, func(ctx context.Context) error {
+		_, err := mintClient.Inflation(ctx, &minttypes.QueryInflationRequest{})
+		return err
+	})
+
+	run("mint", "AnnualProvisions", "", []string{"cosmos_mint_annual_provisions_tokens"}, func(ctx context.Context) error {
+		_, err := mintClient.AnnualProvisions(ctx, &minttypes.QueryAnnualProvisionsRequest{})
+		return err
+	})
+
+	run("distribution", "Params", "", []string{"tendermint_community_tax_rate"}, func(ctx context.Context) error {
+		_, err := distributionClient.Params(ctx, &distributiontypes.QueryParamsRequest{})
+		return err
+	})
+
+	run("distribution", "CommunityPool", "", []string{"cosmos_distribution_community_pool_tokens"}, func(ctx context.Context) error {
+		_, err := distributionClient.CommunityPool(ctx, &distributiontypes.QueryCommunityPoolRequest{})
+		return err
+	})
+
+	// Collectors silently skip values whose denom has no metadata
+	denomMetrics := []struct {
+		denom   string
+		metrics []string
+	}{
+		{collector.defaultBondDenom, []string{"tendermint_staking_total", "tendermint_validator_voting_power_total", "tendermint_bonded_token", "tendermint_not_bonded_token"}},
+		{collector.defaultMintDenom, []string{"tendermint_staking_reward_total", "tendermint_circulating_supply"}},
+	}
+	for _, d := range denomMetrics {
+		var err error
+		if _, found := collector.denomMetadata[d.denom]; !found {
+			err = &types.DenomNotFound{}
+		}
+		checks = append(checks, Check{
+			Module:  "denom",
+			Query:   "metadata",
+			Target:  d.denom,
+			Metrics: d.metrics,
+			Err:     err,
+		})
+	}
+
+	return checks
+}
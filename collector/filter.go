@@ -0,0 +1,144 @@
+package collector
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+
+	types "github.com/forbole/cosmos-exporter/types"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/model"
+)
+
+// MetricsFilter renames the gathered metrics, adds constant labels to them
+// and drops the metric families not matching the allow and deny lists
+type MetricsFilter struct {
+	// mu guards the settings replaced by a reload
+	mu          sync.RWMutex
+	naming      string
+	constLabels []*dto.LabelPair
+	allow       []*regexp.Regexp
+	deny        []*regexp.Regexp
+}
+
+// NewMetricsFilter validates the naming and label names and compiles the
+// allow and deny patterns, which must match the whole exported metric name
+func NewMetricsFilter(cfg types.Metrics) (*MetricsFilter, error) {
+	if err := validateNaming(cfg.Naming); err != nil {
+		return nil, err
+	}
+	filter := &MetricsFilter{naming: cfg.Naming}
+
+	for name, value := range cfg.ConstLabels {
+		if !model.LabelName(name).IsValid() {
+			return nil, fmt.Errorf("invalid constant label name %q", name)
+		}
+		filter.constLabels = append(filter.constLabels, &dto.LabelPair{Name: proto(name), Value: proto(value)})
+	}
+
+	var err error
+	if filter.allow, err = compilePatterns(cfg.Allow); err != nil {
+		return nil, err
+	}
+	if filter.deny, err = compilePatterns(cfg.Deny); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// Replace applies the settings of other, a filter built from a reloaded config
+func (f *MetricsFilter) Replace(other *MetricsFilter) {
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.naming, f.constLabels, f.allow, f.deny = other.naming, other.constLabels, other.allow, other.deny
+}
+
+// Wrap returns a gatherer applying the filter to the metrics gathered by g
+func (f *MetricsFilter) Wrap(g prometheus.Gatherer) prometheus.Gatherer {
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		families, err := g.Gather()
+
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+
+		var kept []*dto.MetricFamily
+		for _, family := range families {
+			labelsAdded := false
+			for _, renamed := range rename(family, f.naming) {
+				if !f.keep(renamed.GetName()) {
+					continue
+				}
+				// Renamed families share their metrics with the original one
+				if !labelsAdded {
+					for _, metric := range family.Metric {
+						metric.Label = f.addConstLabels(metric.Label)
+					}
+					labelsAdded = true
+				}
+				kept = append(kept, renamed)
+			}
+		}
+		sort.Slice(kept, func(i, j int) bool {
+			return kept[i].GetName() < kept[j].GetName()
+		})
+		return kept, err
+	})
+}
+
+func (f *MetricsFilter) keep(name string) bool {
+	if len(f.allow) > 0 && !matchAny(f.allow, name) {
+		return false
+	}
+	return !matchAny(f.deny, name)
+}
+
+// addConstLabels appends the constant labels to labels, a label already set
+// by the metric keeps its value
+func (f *MetricsFilter) addConstLabels(labels []*dto.LabelPair) []*dto.LabelPair {
+	if len(f.constLabels) == 0 {
+		return labels
+	}
+
+	set := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		set[label.GetName()] = true
+	}
+	for _, label := range f.constLabels {
+		if !set[label.GetName()] {
+			labels = append(labels, label)
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].GetName() < labels[j].GetName()
+	})
+	return labels
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid metric name pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+func matchAny(patterns []*regexp.Regexp, name string) bool {
+	for _, re := range patterns {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func proto(s string) *string {
+	return &s
+}
@@ -0,0 +1,181 @@
+package collector
+
+import (
+	"reflect"
+	"testing"
+
+	types "github.com/forbole/cosmos-exporter/types"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// testRegistry returns a registry with a renamed legacy metric and a metric
+// which only has a cosmos_* name
+func testRegistry(t *testing.T) *prometheus.Registry {
+	t.Helper()
+
+	inflation := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "tendermint_inflation_rate", Help: "test"}, []string{"chain_id"})
+	inflation.WithLabelValues("test-1").Set(0.07)
+	maxValidators := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "cosmos_staking_max_validators", Help: "test"}, []string{"chain_id", "env"})
+	maxValidators.WithLabelValues("test-1", "staging").Set(100)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(inflation, maxValidators)
+	return registry
+}
+
+func familyNames(families []*dto.MetricFamily) []string {
+	names := []string{}
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+	return names
+}
+
+func TestMetricsFilterNames(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  types.Metrics
+		want []string
+	}{
+		{
+			name: "legacy by default",
+			cfg:  types.Metrics{},
+			want: []string{"cosmos_staking_max_validators", "tendermint_inflation_rate"},
+		},
+		{
+			name: "new",
+			cfg:  types.Metrics{Naming: NamingNew},
+			want: []string{"cosmos_mint_inflation_ratio", "cosmos_staking_max_validators"},
+		},
+		{
+			name: "both",
+			cfg:  types.Metrics{Naming: NamingBoth},
+			want: []string{"cosmos_mint_inflation_ratio", "cosmos_staking_max_validators", "tendermint_inflation_rate"},
+		},
+		{
+			name: "allow matches the whole name",
+			cfg:  types.Metrics{Allow: []string{"cosmos_staking_max"}},
+			want: []string{},
+		},
+		{
+			name: "allow pattern",
+			cfg:  types.Metrics{Allow: []string{"cosmos_staking_.*"}},
+			want: []string{"cosmos_staking_max_validators"},
+		},
+		{
+			name: "deny pattern",
+			cfg:  types.Metrics{Deny: []string{"cosmos_staking_.*"}},
+			want: []string{"tendermint_inflation_rate"},
+		},
+		{
+			name: "deny wins over allow",
+			cfg:  types.Metrics{Allow: []string{".*"}, Deny: []string{"tendermint_.*"}},
+			want: []string{"cosmos_staking_max_validators"},
+		},
+		{
+			name: "allow and deny match the exported name",
+			cfg:  types.Metrics{Naming: NamingBoth, Deny: []string{"tendermint_.*"}},
+			want: []string{"cosmos_mint_inflation_ratio", "cosmos_staking_max_validators"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewMetricsFilter(tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			families, err := filter.Wrap(testRegistry(t)).Gather()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := familyNames(families); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("families = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetricsFilterConstLabels(t *testing.T) {
+	filter, err := NewMetricsFilter(types.Metrics{
+		Naming:      NamingBoth,
+		ConstLabels: map[string]string{"env": "prod", "region": "eu"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	families, err := filter.Wrap(testRegistry(t)).Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]map[string]string{
+		"cosmos_mint_inflation_ratio":   {"chain_id": "test-1", "env": "prod", "region": "eu"},
+		"tendermint_inflation_rate":     {"chain_id": "test-1", "env": "prod", "region": "eu"},
+		"cosmos_staking_max_validators": {"chain_id": "test-1", "env": "staging", "region": "eu"},
+	}
+	for _, family := range families {
+		if len(family.Metric) != 1 {
+			t.Fatalf("%s has %d metrics, want 1", family.GetName(), len(family.Metric))
+		}
+		got := make(map[string]string)
+		for _, label := range family.Metric[0].Label {
+			if _, found := got[label.GetName()]; found {
+				t.Errorf("%s has the label %s twice", family.GetName(), label.GetName())
+			}
+			got[label.GetName()] = label.GetValue()
+		}
+		if !reflect.DeepEqual(got, want[family.GetName()]) {
+			t.Errorf("labels of %s = %v, want %v", family.GetName(), got, want[family.GetName()])
+		}
+	}
+}
+
+func TestNewMetricsFilterErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  types.Metrics
+	}{
+		{"invalid naming", types.Metrics{Naming: "old"}},
+		{"invalid label name", types.Metrics{ConstLabels: map[string]string{"1env": "prod"}}},
+		{"invalid allow pattern", types.Metrics{Allow: []string{"cosmos_("}}},
+		{"invalid deny pattern", types.Metrics{Deny: []string{"["}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMetricsFilter(tt.cfg); err == nil {
+				t.Error("NewMetricsFilter accepted an invalid config")
+			}
+		})
+	}
+}
+
+func TestMetricsFilterReplace(t *testing.T) {
+	filter, err := NewMetricsFilter(types.Metrics{Deny: []string{"cosmos_staking_.*"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gatherer := filter.Wrap(testRegistry(t))
+
+	families, err := gatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := familyNames(families), []string{"tendermint_inflation_rate"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("names before replace = %v, want %v", got, want)
+	}
+
+	reloaded, err := NewMetricsFilter(types.Metrics{Naming: NamingNew})
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter.Replace(reloaded)
+
+	families, err = gatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := familyNames(families), []string{"cosmos_mint_inflation_ratio", "cosmos_staking_max_validators"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("names after replace = %v, want %v", got, want)
+	}
+}
@@ -2,31 +2,42 @@ package collector
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"math"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/cosmos/cosmos-sdk/x/mint/types"
+	denomtypes "github.com/forbole/cosmos-exporter/types"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-const defaultTimeout = 5 * time.Second
-
-func (collector *CosmosSDKCollector) CollectInflationRate() {
+func (collector *CosmosSDKCollector) CollectInflationRate(ctx context.Context) error {
 	if collector.sdkVersion == SDKVersionLegacy {
-		collector.collectInflationRateLegacy()
-	} else {
-		collector.collectInflationRateCurrent()
+		return collector.collectInflationRateLegacy(ctx)
 	}
+	return collector.collectInflationRateCurrent(ctx)
 }
 
 // Implementation for pre-v0.50.x chains
-func (collector *CosmosSDKCollector) collectInflationRateLegacy() {
+func (collector *CosmosSDKCollector) collectInflationRateLegacy(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
 	mintClient := types.NewQueryClient(collector.grpcConn)
 
-	// Try to get annual provisions and total supply to calculate inflation
+	inflationRes, err := mintClient.Inflation(ctx, &types.QueryInflationRequest{})
+	if err == nil {
+		if inflation, err := inflationRes.Inflation.Float64(); err == nil {
+			collector.setInflationRate(inflation)
+			return nil
+		}
+	}
+	log.Printf("Error getting inflation rate (legacy), falling back to annual provisions: %v", err)
+
 	annualProvisionsRes, err := mintClient.AnnualProvisions(
-		context.Background(),
+		ctx,
 		&types.QueryAnnualProvisionsRequest{},
 	)
 
@@ -35,31 +46,29 @@ func (collector *CosmosSDKCollector) collectInflationRateLegacy() {
 		// or it's using a custom mint module
 		ErrorGauge.WithLabelValues("tendermint_inflation_rate").Inc()
 		log.Printf("Error getting inflation rate (legacy): %v", err)
-		return
+		collector.clearInflation()
+		return err
 	}
 
-	// Calculate inflation rate by using annual provisions and total supply from bank module
-	// This is a simplified approach; chains might have custom inflation calculation
 	annualProvisions, err := annualProvisionsRes.AnnualProvisions.Float64()
 	if err != nil {
 		ErrorGauge.WithLabelValues("tendermint_inflation_rate").Inc()
 		log.Printf("Error parsing annual provisions (legacy): %v", err)
-		return
+		collector.clearInflation()
+		return err
 	}
-
-	// Set inflation rate metric
-	InflationRate.WithLabelValues(collector.chainID).Set(annualProvisions)
+	return collector.setAnnualProvisions(annualProvisions)
 }
 
 // Implementation for v0.50.x chains
-func (collector *CosmosSDKCollector) collectInflationRateCurrent() {
+func (collector *CosmosSDKCollector) collectInflationRateCurrent(ctx context.Context) (err error) {
 	// In Cosmos SDK v0.50.x, there are protobuf compatibility issues with the mint module
 	// We'll use a simple approach that catches errors and falls back gracefully
 
 	// Try to get inflation rate via params
 	mintClient := types.NewQueryClient(collector.grpcConn)
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	defer cancel()
 
 	// Try to get params directly - this is more likely to work across chains
@@ -72,8 +81,8 @@ func (collector *CosmosSDKCollector) collectInflationRateCurrent() {
 		// Try to extract inflation values from the string
 		for _, key := range []string{"inflation_rate", "inflation"} {
 			if val := extractFloatFromString(paramsStr, key); val > 0 {
-				InflationRate.WithLabelValues(collector.chainID).Set(val)
-				return
+				collector.setInflationRate(val)
+				return nil
 			}
 		}
 	} else {
@@ -84,25 +93,71 @@ func (collector *CosmosSDKCollector) collectInflationRateCurrent() {
 	defer func() {
 		if r := recover(); r != nil {
 			log.Printf("Recovered from panic in AnnualProvisions: %v", r)
+			ErrorGauge.WithLabelValues("tendermint_inflation_rate").Inc()
+			err = fmt.Errorf("panic in AnnualProvisions: %v", r)
 		}
 	}()
 
+	inflationRes, err := mintClient.Inflation(ctx, &types.QueryInflationRequest{})
+	if err == nil && inflationRes != nil {
+		if val, err := inflationRes.Inflation.Float64(); err == nil {
+			collector.setInflationRate(val)
+			return nil
+		}
+	}
+
+	// Annual provisions are a token amount, exported under their own name
 	annualProvisionsRes, err := mintClient.AnnualProvisions(ctx, &types.QueryAnnualProvisionsRequest{})
 	if err == nil && annualProvisionsRes != nil {
-		// Try to safely get the value as a string
-		valStr := annualProvisionsRes.String()
-		if strings.Contains(valStr, "annual_provisions:") {
-			val := extractFloatFromString(valStr, "annual_provisions")
-			if val > 0 {
-				InflationRate.WithLabelValues(collector.chainID).Set(val)
-				return
-			}
+		// The string form holds the raw bytes of the decimal, read its value
+		if val, err := annualProvisionsRes.AnnualProvisions.Float64(); err == nil && val > 0 {
+			return collector.setAnnualProvisions(val)
 		}
 	}
 
-	// Last resort - set a default value
-	log.Print("Setting default inflation rate value due to v0.50.x compatibility issues")
-	InflationRate.WithLabelValues(collector.chainID).Set(0)
+	// Nothing could be read, a stale or made up value would be misleading
+	log.Print("Inflation rate not found due to v0.50.x compatibility issues, clearing it")
+	ErrorGauge.WithLabelValues("tendermint_inflation_rate").Inc()
+	collector.clearInflation()
+	return fmt.Errorf("inflation rate not found in mint params nor annual provisions")
+}
+
+// setInflationRate exports the inflation ratio, which replaces the annual
+// provisions fallback
+func (collector *CosmosSDKCollector) setInflationRate(inflation float64) {
+	labels := prometheus.Labels{"chain_id": collector.chainID}
+	setLegacyOnly("tendermint_inflation_rate", labels, false)
+	InflationRate.With(labels).Set(inflation)
+	AnnualProvisionsGauge.DeletePartialMatch(labels)
+}
+
+// setAnnualProvisions exports the annual provisions, given in the base mint
+// denom, when the inflation ratio is not available. They are also exported
+// unscaled as the legacy tendermint_inflation_rate, as they were before the
+// rename, but not as cosmos_mint_inflation_ratio.
+func (collector *CosmosSDKCollector) setAnnualProvisions(annualProvisions float64) error {
+	labels := prometheus.Labels{"chain_id": collector.chainID}
+	setLegacyOnly("tendermint_inflation_rate", labels, true)
+	InflationRate.With(labels).Set(annualProvisions)
+
+	baseDenom, found := collector.denomMetadata[collector.defaultMintDenom]
+	if !found {
+		ErrorGauge.WithLabelValues("cosmos_mint_annual_provisions_tokens").Inc()
+		log.Print("No denom infos")
+		AnnualProvisionsGauge.DeletePartialMatch(labels)
+		return &denomtypes.DenomNotFound{}
+	}
+	AnnualProvisionsGauge.WithLabelValues(collector.chainID, baseDenom.Display).Set(annualProvisions / math.Pow10(int(baseDenom.Exponent)))
+	return nil
+}
+
+// clearInflation deletes the inflation ratio and annual provisions of the
+// chain when neither can be read
+func (collector *CosmosSDKCollector) clearInflation() {
+	labels := prometheus.Labels{"chain_id": collector.chainID}
+	setLegacyOnly("tendermint_inflation_rate", labels, false)
+	InflationRate.Delete(labels)
+	AnnualProvisionsGauge.DeletePartialMatch(labels)
 }
 
 // Helper function to extract float values from string representations
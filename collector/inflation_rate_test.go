@@ -0,0 +1,122 @@
+package collector
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	types "github.com/forbole/cosmos-exporter/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeMint answers the mint queries, failing those whose value is empty
+type fakeMint struct {
+	minttypes.UnimplementedQueryServer
+	inflation        string
+	annualProvisions string
+}
+
+func (s *fakeMint) Params(ctx context.Context, req *minttypes.QueryParamsRequest) (*minttypes.QueryParamsResponse, error) {
+	return &minttypes.QueryParamsResponse{Params: minttypes.DefaultParams()}, nil
+}
+
+func (s *fakeMint) Inflation(ctx context.Context, req *minttypes.QueryInflationRequest) (*minttypes.QueryInflationResponse, error) {
+	if s.inflation == "" {
+		return nil, status.Error(codes.Unimplemented, "no inflation")
+	}
+	return &minttypes.QueryInflationResponse{Inflation: sdkmath.LegacyMustNewDecFromStr(s.inflation)}, nil
+}
+
+func (s *fakeMint) AnnualProvisions(ctx context.Context, req *minttypes.QueryAnnualProvisionsRequest) (*minttypes.QueryAnnualProvisionsResponse, error) {
+	if s.annualProvisions == "" {
+		return nil, status.Error(codes.Unimplemented, "no annual provisions")
+	}
+	return &minttypes.QueryAnnualProvisionsResponse{AnnualProvisions: sdkmath.LegacyMustNewDecFromStr(s.annualProvisions)}, nil
+}
+
+func TestCollectInflationRate(t *testing.T) {
+	tests := []struct {
+		name string
+		mint *fakeMint
+		// want are the values exported by name with each naming
+		want map[string]map[string]float64
+	}{
+		{
+			name: "inflation",
+			mint: &fakeMint{inflation: "0.07", annualProvisions: "2000000000000"},
+			want: map[string]map[string]float64{
+				NamingLegacy: {"tendermint_inflation_rate": 0.07},
+				NamingNew:    {"cosmos_mint_inflation_ratio": 0.07},
+				NamingBoth:   {"tendermint_inflation_rate": 0.07, "cosmos_mint_inflation_ratio": 0.07},
+			},
+		},
+		{
+			name: "annual provisions only",
+			mint: &fakeMint{annualProvisions: "2000000000000"},
+			want: map[string]map[string]float64{
+				NamingLegacy: {"tendermint_inflation_rate": 2e12, "cosmos_mint_annual_provisions_tokens": 2e6},
+				NamingNew:    {"cosmos_mint_annual_provisions_tokens": 2e6},
+				NamingBoth:   {"tendermint_inflation_rate": 2e12, "cosmos_mint_annual_provisions_tokens": 2e6},
+			},
+		},
+		{
+			name: "nothing can be read",
+			mint: &fakeMint{},
+			want: map[string]map[string]float64{
+				NamingLegacy: {},
+				NamingNew:    {},
+				NamingBoth:   {},
+			},
+		},
+	}
+	for _, sdkVersion := range []SDKVersion{SDKVersionCurrent, SDKVersionLegacy} {
+		for _, tt := range tests {
+			t.Run(string(sdkVersion)+" "+tt.name, func(t *testing.T) {
+				// The default codec of the exporter cannot decode the mint
+				// responses of current SDK versions
+				conn := newTestConn(t, func(server *grpc.Server) {
+					minttypes.RegisterQueryServer(server, tt.mint)
+				}, grpc.WithDefaultCallOptions(grpc.ForceCodec(sdkCodec())))
+				collector := newTestCollector(conn, "")
+				collector.sdkVersion = sdkVersion
+				defer collector.clearInflation()
+
+				// Values of a previous collection are replaced or cleared
+				collector.setInflationRate(0.5)
+				AnnualProvisionsGauge.WithLabelValues(collector.chainID, "atom").Set(1)
+
+				err := collector.CollectInflationRate(context.Background())
+				if wantErr := len(tt.want[NamingLegacy]) == 0; (err != nil) != wantErr {
+					t.Errorf("CollectInflationRate() error = %v, want error %v", err, wantErr)
+				}
+
+				registry := prometheus.NewRegistry()
+				registry.MustRegister(InflationRate, AnnualProvisionsGauge)
+				for naming, want := range tt.want {
+					filter, err := NewMetricsFilter(types.Metrics{Naming: naming})
+					if err != nil {
+						t.Fatal(err)
+					}
+					families, err := filter.Wrap(registry).Gather()
+					if err != nil {
+						t.Fatal(err)
+					}
+					got := make(map[string]float64)
+					for _, family := range families {
+						for _, metric := range family.Metric {
+							got[family.GetName()] = metric.GetGauge().GetValue()
+						}
+					}
+					if !reflect.DeepEqual(got, want) {
+						t.Errorf("%s naming: exported %v, want %v", naming, got, want)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package collector
+
+import (
+	"context"
+	"time"
+
+	cmthttp "github.com/cometbft/cometbft/rpc/client/http"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// namedCollector pairs a collector with the name used in the exporter self
+// metrics and the gauges whose series it sets for a variable set of labels
+type namedCollector struct {
+	name    string
+	collect func(context.Context) error
+	gauges  []*prometheus.GaugeVec
+}
+
+// CollectorResult describes a single run of a collector
+type CollectorResult struct {
+	Name     string
+	Start    time.Time
+	Duration time.Duration
+	Err      error
+}
+
+// observeCollector runs a collector and records its duration and last success
+// time. The series of its gauges which were not set anymore are then deleted.
+// A failed run keeps them with their last value, only for the failed
+// addresses when the other ones succeeded.
+func observeCollector(ctx context.Context, c namedCollector) CollectorResult {
+	tracker := newSeriesTracker()
+
+	start := time.Now()
+	err := c.collect(context.WithValue(ctx, seriesTrackerKey{}, tracker))
+	duration := time.Since(start)
+
+	CollectorDurationGauge.WithLabelValues(c.name).Set(duration.Seconds())
+	switch failed := err.(type) {
+	case nil:
+		CollectorLastSuccessGauge.WithLabelValues(c.name).SetToCurrentTime()
+		tracker.sweep(c.gauges, nil)
+	case *addressErrors:
+		tracker.sweep(c.gauges, failed.addresses)
+	default:
+		tracker.keep(c.gauges)
+	}
+	return CollectorResult{Name: c.name, Start: start, Duration: duration, Err: err}
+}
+
+// InstrumentGRPC returns a gRPC interceptor recording the duration and
+// the errors of every query by method and status code
+func InstrumentGRPC() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		code := status.Code(err).String()
+
+		GRPCRequestDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+		if err != nil {
+			GRPCRequestErrors.WithLabelValues(method, code).Inc()
+		}
+		return err
+	}
+}
+
+// observeRPC runs a CometBFT RPC call and records its duration and errors
+func observeRPC(method string, call func() error) error {
+	start := time.Now()
+	err := call()
+
+	result := "success"
+	if err != nil {
+		result = "error"
+		RPCRequestErrors.WithLabelValues(method).Inc()
+	}
+	RPCRequestDuration.WithLabelValues(method, result).Observe(time.Since(start).Seconds())
+	return err
+}
+
+// nodeStatus calls the CometBFT /status endpoint, recording the call in the self metrics
+func nodeStatus(ctx context.Context, client *cmthttp.HTTP) (*coretypes.ResultStatus, error) {
+	var status *coretypes.ResultStatus
+	err := observeRPC("status", func() error {
+		var err error
+		status, err = client.Status(ctx)
+		return err
+	})
+	return status, err
+}
+
+// nodeValidators pages through the CometBFT /validators endpoint at the
+// latest height, recording the calls in the self metrics
+func nodeValidators(ctx context.Context, client *cmthttp.HTTP) ([]*cmttypes.Validator, error) {
+	var validators []*cmttypes.Validator
+	perPage := 100
+	for page := 1; ; page++ {
+		var res *coretypes.ResultValidators
+		err := observeRPC("validators", func() error {
+			var err error
+			res, err = client.Validators(ctx, nil, &page, &perPage)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		validators = append(validators, res.Validators...)
+		if len(res.Validators) == 0 || len(validators) >= res.Total {
+			return validators, nil
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package collector
+
+import (
+	"context"
+	"log"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	types "github.com/forbole/cosmos-exporter/types"
+)
+
+// SetLabels replaces the moniker and address labels config
+func (c *CosmosSDKCollector) SetLabels(labels types.Labels) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.labels = labels
+}
+
+func (c *CosmosSDKCollector) labelsConfig() types.Labels {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.labels
+}
+
+// addressLabels returns the alias, owner and team labels configured for address
+func (c *CosmosSDKCollector) addressLabels(address string) types.AddressLabels {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.labels.Addresses[address]
+}
+
+// validatorMoniker returns the moniker label of the monitored validator,
+// empty when monikers are disabled
+func (c *CosmosSDKCollector) validatorMoniker() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.moniker
+}
+
+// monikerOf returns the moniker label of validator, empty when monikers are disabled
+func (c *CosmosSDKCollector) monikerOf(validator stakingtypes.Validator) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.labels.ValidatorMoniker {
+		return ""
+	}
+	return validator.Description.Moniker
+}
+
+// refreshMoniker looks up the moniker of the monitored validator before a
+// collection, keeping the previous one when the query fails. The validator
+// series are deleted when the moniker changes so they are not duplicated.
+func (c *CosmosSDKCollector) refreshMoniker(ctx context.Context) {
+	valAddress := c.validatorAddress()
+	var moniker string
+	if valAddress != "" && c.labelsConfig().ValidatorMoniker {
+		ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+
+		stakingClient := stakingtypes.NewQueryClient(c.grpcConn)
+		res, err := stakingClient.Validator(ctx, &stakingtypes.QueryValidatorRequest{ValidatorAddr: valAddress})
+		if err != nil {
+			log.Printf("Error getting the moniker of %s: %v", valAddress, err)
+			return
+		}
+		moniker = c.monikerOf(res.Validator)
+	}
+
+	c.mu.Lock()
+	changed := moniker != c.moniker
+	c.moniker = moniker
+	c.mu.Unlock()
+
+	if changed {
+		DeleteValidatorSeries(c.chainID, valAddress)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"strings"
+	"sync"
 	"time"
 
 	cmthttp "github.com/cometbft/cometbft/rpc/client/http"
@@ -12,9 +13,13 @@ import (
 	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	types "github.com/forbole/cosmos-exporter/types"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 )
 
+// defaultTimeout bounds every single query made by the collectors
+const defaultTimeout = 5 * time.Second
+
 type SDKVersion string
 
 const (
@@ -23,22 +28,39 @@ const (
 )
 
 type CosmosSDKCollector struct {
-	grpcConn *grpc.ClientConn
-	//https://docs.cosmos.network/master/basics/accounts.html
-	valAddress       string
-	accAddresses     []string
+	grpcConn         *grpc.ClientConn
+	rpcClient        *cmthttp.HTTP
 	chainID          string
 	denomMetadata    map[string]types.DenomMetadata
 	defaultBondDenom string
 	defaultMintDenom string
 	sdkVersion       SDKVersion
+	concurrency      types.Concurrency
+
+	// mu guards the settings which can be reloaded, read by the collectors
+	// and /probe requests
+	mu sync.RWMutex
+	//https://docs.cosmos.network/master/basics/accounts.html
+	valAddress   string
+	accAddresses []string
+	validatorSet types.ValidatorSet
+	validator    types.Validator
+	delegators   types.Delegators
+	labels       types.Labels
+	balances     types.Balances
+	moniker      string
+
+	// The histories outlive the collector when a reload dials the node again
+	delegations    *delegationHistory
+	accounts       *accountHistory
+	balanceHistory *balanceHistory
 }
 
 // Detect SDK version based on API behavior
-func detectSDKVersion(grpcConn *grpc.ClientConn, rpcConn string) SDKVersion {
+func detectSDKVersion(ctx context.Context, grpcConn *grpc.ClientConn, rpcClient *cmthttp.HTTP) SDKVersion {
 	// First check the Params API format which differs between versions
 	stakingClient := stakingtypes.NewQueryClient(grpcConn)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
 	defer cancel()
 
 	// Try to get staking params
@@ -98,24 +120,22 @@ func detectSDKVersion(grpcConn *grpc.ClientConn, rpcConn string) SDKVersion {
 	log.Printf("SDK version not definitively detected, attempting one more check...")
 
 	// As a final check, try to determine version based on CometBFT/Tendermint response format
-	if client, err := cmthttp.New(rpcConn, "/websocket"); err == nil {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
-		defer cancel()
-
-		if status, err := client.Status(ctx); err == nil {
-			if status.NodeInfo.Version != "" {
-				version := status.NodeInfo.Version
-				// Check if it's CometBFT (v0.50.x) or Tendermint (pre-v0.50.x)
-				if strings.Contains(strings.ToLower(version), "comet") ||
-					strings.HasPrefix(version, "0.37.") ||
-					strings.HasPrefix(version, "0.38.") {
-					log.Printf("Detected current SDK version (v0.50.x) based on CometBFT version")
-					return SDKVersionCurrent
-				} else if strings.HasPrefix(version, "0.34.") ||
-					strings.Contains(strings.ToLower(version), "tendermint") {
-					log.Printf("Detected legacy SDK version based on Tendermint version")
-					return SDKVersionLegacy
-				}
+	rpcCtx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	if status, err := nodeStatus(rpcCtx, rpcClient); err == nil {
+		if status.NodeInfo.Version != "" {
+			version := status.NodeInfo.Version
+			// Check if it's CometBFT (v0.50.x) or Tendermint (pre-v0.50.x)
+			if strings.Contains(strings.ToLower(version), "comet") ||
+				strings.HasPrefix(version, "0.37.") ||
+				strings.HasPrefix(version, "0.38.") {
+				log.Printf("Detected current SDK version (v0.50.x) based on CometBFT version")
+				return SDKVersionCurrent
+			} else if strings.HasPrefix(version, "0.34.") ||
+				strings.Contains(strings.ToLower(version), "tendermint") {
+				log.Printf("Detected legacy SDK version based on Tendermint version")
+				return SDKVersionLegacy
 			}
 		}
 	}
@@ -125,31 +145,45 @@ func detectSDKVersion(grpcConn *grpc.ClientConn, rpcConn string) SDKVersion {
 	return SDKVersionCurrent
 }
 
-func NewCosmosSDKCollector(grpcConn *grpc.ClientConn, rpcConn string, valAddress string, accAddresses []string, customDenomData types.DenomMetadata) CosmosSDKCollector {
-	chainID := getChainID(rpcConn)
+// Options are the monitored addresses and the settings of a CosmosSDKCollector
+type Options struct {
+	ValidatorAddress   string
+	DelegatorAddresses []string
+	DenomMetadata      types.DenomMetadata
+	Concurrency        types.Concurrency
+	Labels             types.Labels
+	Balances           types.Balances
+	ValidatorSet       types.ValidatorSet
+	Validator          types.Validator
+	Delegators         types.Delegators
+}
+
+func NewCosmosSDKCollector(ctx context.Context, grpcConn *grpc.ClientConn, rpcClient *cmthttp.HTTP, opts Options) *CosmosSDKCollector {
+	customDenomData := opts.DenomMetadata
+	chainID := getChainID(ctx, rpcClient)
 
 	// Detect SDK version
-	sdkVersion := detectSDKVersion(grpcConn, rpcConn)
+	sdkVersion := detectSDKVersion(ctx, grpcConn, rpcClient)
 
 	denomsMetadata := make(map[string]types.DenomMetadata)
 
 	// Use version-appropriate code
 	if sdkVersion == SDKVersionLegacy {
-		addDenomsMetadataLegacy(grpcConn, denomsMetadata)
+		addDenomsMetadataLegacy(ctx, grpcConn, denomsMetadata)
 	} else {
-		addDenomsMetadata(grpcConn, denomsMetadata)
+		addDenomsMetadata(ctx, grpcConn, denomsMetadata)
 	}
 
 	addCustomDenomMetadata(customDenomData, denomsMetadata)
 
 	var defaultMintDenom string
 	var defaultBondDenom string
-	if denom, err := getMintDenom(grpcConn); err != nil {
+	if denom, err := getMintDenom(ctx, grpcConn); err != nil {
 		defaultMintDenom = customDenomData.Base
 	} else {
 		defaultMintDenom = denom
 	}
-	if denom, err := getBondDenom(grpcConn); err != nil {
+	if denom, err := getBondDenom(ctx, grpcConn); err != nil {
 		defaultBondDenom = customDenomData.Base
 	} else {
 		defaultBondDenom = denom
@@ -158,45 +192,151 @@ func NewCosmosSDKCollector(grpcConn *grpc.ClientConn, rpcConn string, valAddress
 	// Ensure we have at least basic metadata even if the RPC fails
 	ensureMinimumDenomMetadata(denomsMetadata, customDenomData.Base)
 
-	return CosmosSDKCollector{
+	collector := &CosmosSDKCollector{
 		grpcConn:         grpcConn,
+		rpcClient:        rpcClient,
 		chainID:          chainID,
-		valAddress:       valAddress,
-		accAddresses:     accAddresses,
+		valAddress:       opts.ValidatorAddress,
+		accAddresses:     opts.DelegatorAddresses,
 		denomMetadata:    denomsMetadata,
 		defaultBondDenom: defaultBondDenom,
 		defaultMintDenom: defaultMintDenom,
 		sdkVersion:       sdkVersion,
+		concurrency:      opts.Concurrency.WithDefaults(),
+		validatorSet:     opts.ValidatorSet.WithDefaults(),
+		validator:        opts.Validator.WithDefaults(),
+		delegators:       opts.Delegators.WithDefaults(),
+		labels:           opts.Labels,
+		delegations:      &delegationHistory{},
+		accounts:         &accountHistory{},
+		balanceHistory:   &balanceHistory{},
 	}
+	collector.SetBalances(opts.Balances)
+	return collector
 }
 
-func (c *CosmosSDKCollector) CollectChainMetrics() {
-	c.CollectActiveProposal()
-	c.CollectAvailableBalance()
-	c.CollectDeleatorReward()
-	c.CollecDelegatorStake()
-	c.CollectValidatorCommissionGauge()
-	c.CollectValidatorDelegationGauge()
-	c.CollectValidatorStat()
-	c.CollectValidatorsStat()
-	c.CollectCirculatingSupply()
-	c.CollectInflationRate()
-	c.CollectCommunityTax()
-	c.CollectUnbondingTime()
+// KeepHistories makes c continue the delegation, account and balance
+// histories of previous, the collector of the same chain it replaces
+func (c *CosmosSDKCollector) KeepHistories(previous *CosmosSDKCollector) {
+	c.delegations = previous.delegations
+	c.accounts = previous.accounts
+	c.balanceHistory = previous.balanceHistory
 }
 
-// Find Chain id to add as metrics lable
-func getChainID(rpc string) string {
-	client, err := cmthttp.New(rpc, "/websocket")
-	if err != nil {
-		log.Printf("Error creating RPC client: %v", err)
-		return "unknown-chain"
+// SetAddresses replaces the monitored validator and delegator addresses
+func (c *CosmosSDKCollector) SetAddresses(valAddress string, accAddresses []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.valAddress = valAddress
+	c.accAddresses = accAddresses
+}
+
+func (c *CosmosSDKCollector) validatorAddress() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.valAddress
+}
+
+func (c *CosmosSDKCollector) delegatorAddresses() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.accAddresses
+}
+
+// SetValidator replaces the settings of the monitored validator metrics
+func (c *CosmosSDKCollector) SetValidator(validator types.Validator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.validator = validator.WithDefaults()
+}
+
+func (c *CosmosSDKCollector) validatorConfig() types.Validator {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.validator
+}
+
+// SetDelegators replaces the settings of the delegators metrics
+func (c *CosmosSDKCollector) SetDelegators(delegators types.Delegators) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.delegators = delegators.WithDefaults()
+}
+
+func (c *CosmosSDKCollector) delegatorsConfig() types.Delegators {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.delegators
+}
+
+// collectors lists every collector with the name used in the exporter self metrics
+func (c *CosmosSDKCollector) collectors() []namedCollector {
+	return []namedCollector{
+		{"active_proposal", c.CollectActiveProposal, []*prometheus.GaugeVec{ActiveProposalGauge, VotedActiveProposalGauge}},
+		{"available_balance", c.CollectAvailableBalance, []*prometheus.GaugeVec{AvailableBalanceGauge, BalanceThresholdGauge, BalanceBelowThresholdGauge, BalanceSpendRateGauge, BalanceDepletionGauge}},
+		{"delegator_reward", c.CollectDeleatorReward, []*prometheus.GaugeVec{DelegatorRewardGauge}},
+		{"delegator_stake", c.CollecDelegatorStake, []*prometheus.GaugeVec{DelegatorStakeGauge}},
+		{"validator_commission", c.CollectValidatorCommissionGauge, []*prometheus.GaugeVec{ValidatorCommissionGauge}},
+		{"validator_delegation_count", c.CollectValidatorDelegationGauge, []*prometheus.GaugeVec{ValidatorDelegationGauge}},
+		{"validator_status", c.CollectValidatorStat, []*prometheus.GaugeVec{ValidatorJailStatusGauge, ValidatorCommissionRateGauge, ValidatorVotingPowerGauge}},
+		{"validators_status", c.CollectValidatorsStat, []*prometheus.GaugeVec{ValidatorVotingPowerRanking}},
+		{"circulating_supply", c.CollectCirculatingSupply, nil},
+		{"inflation_rate", c.CollectInflationRate, nil},
+		{"community_tax", c.CollectCommunityTax, nil},
+		{"unbonding_time", c.CollectUnbondingTime, nil},
+		{"active_set", c.CollectActiveSet, []*prometheus.GaugeVec{ValidatorBondedRankGauge, ValidatorCutoffGapGauge, ValidatorVotingPowerShareGauge}},
+		{"validator_power", c.CollectValidatorPower, []*prometheus.GaugeVec{ValidatorConsensusPowerGauge, ValidatorCometBFTPowerGauge, ValidatorPowerMismatchGauge}},
+		{"validator_details", c.CollectValidatorDetails, []*prometheus.GaugeVec{ValidatorStatusGauge, ValidatorMinSelfDelegationGauge, ValidatorSelfDelegationGauge, ValidatorSelfDelegationNearMinimumGauge, ValidatorCommissionMaxRateGauge, ValidatorCommissionMaxChangeRateGauge, ValidatorCommissionUpdateTimeGauge, ValidatorUnbondingHeightGauge, ValidatorUnbondingTimeGauge}},
+		{"validator_set", c.CollectValidatorSet, []*prometheus.GaugeVec{SetValidatorJailedGauge, SetValidatorStatusGauge, SetValidatorTokensGauge, SetValidatorCommissionRateGauge}},
+		{"validator_outstanding_rewards", c.CollectValidatorOutstandingRewards, []*prometheus.GaugeVec{ValidatorOutstandingRewardsGauge}},
+		{"validator_slashes", c.CollectValidatorSlashes, []*prometheus.GaugeVec{ValidatorSlashesGauge, ValidatorSlashedRatioGauge, ValidatorLastSlashRatioGauge}},
+		{"community_pool", c.CollectCommunityPool, []*prometheus.GaugeVec{CommunityPoolGauge}},
+		{"validator_delegators", c.CollectValidatorDelegators, []*prometheus.GaugeVec{ValidatorTopDelegatorTokensGauge, ValidatorTopDelegatorRankGauge, ValidatorTopDelegatorsShareGauge, ValidatorDelegationInflowGauge, ValidatorDelegationOutflowGauge, ValidatorNewDelegatorsGauge, ValidatorLostDelegatorsGauge}},
+		{"vesting", c.CollectVesting, []*prometheus.GaugeVec{VestingOriginalGauge, VestingVestedGauge, VestingLockedGauge, VestingDelegatedGauge, VestingNextUnlockGauge, SpendableBalanceGauge}},
+		{"account", c.CollectAccounts, []*prometheus.GaugeVec{AccountNumberGauge, AccountSequenceGauge, AccountSequenceRateGauge, AccountSequenceUnchangedGauge}},
+	}
+}
+
+// CollectChainMetrics runs every collector once using a pool of
+// Concurrency.Collectors workers and returns the result of each run,
+// it stops early when ctx is cancelled
+func (c *CosmosSDKCollector) CollectChainMetrics(ctx context.Context) []CollectorResult {
+	c.refreshMoniker(ctx)
+	ctx = withRound(ctx)
+
+	collectors := c.collectors()
+	results := make([]CollectorResult, len(collectors))
+	tasks := make([]func(context.Context), 0, len(collectors))
+	for i, nc := range collectors {
+		i, nc := i, nc
+		tasks = append(tasks, func(ctx context.Context) {
+			results[i] = observeCollector(ctx, nc)
+		})
 	}
+	runPool(ctx, c.concurrency.Collectors, tasks)
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	// Collectors skipped because of the cancellation have no result
+	ran := results[:0]
+	for _, result := range results {
+		if result.Name != "" {
+			ran = append(ran, result)
+		}
+	}
+	return ran
+}
+
+// Ping checks that the node answers CometBFT RPC calls
+func (c *CosmosSDKCollector) Ping(ctx context.Context) error {
+	_, err := nodeStatus(ctx, c.rpcClient)
+	return err
+}
+
+// Find Chain id to add as metrics lable
+func getChainID(ctx context.Context, client *cmthttp.HTTP) string {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
 
-	status, err := client.Status(ctx)
+	status, err := nodeStatus(ctx, client)
 	if err != nil {
 		log.Printf("Error getting chain status: %v", err)
 		return "unknown-chain"
@@ -206,13 +346,16 @@ func getChainID(rpc string) string {
 }
 
 // Find Denom metadata to convert to human-readable unit (eg. udsm -> dsm)
-func addDenomsMetadata(grpcConn *grpc.ClientConn, denomsMetadata map[string]types.DenomMetadata) {
+func addDenomsMetadata(ctx context.Context, grpcConn *grpc.ClientConn, denomsMetadata map[string]types.DenomMetadata) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
 	bankClient := banktypes.NewQueryClient(grpcConn)
 
 	// In v0.50.x, pagination works differently
 	// Use the v1beta1.PageRequest which has been updated
 	denomsRes, err := bankClient.DenomsMetadata(
-		context.Background(),
+		ctx,
 		&banktypes.QueryDenomsMetadataRequest{
 			Pagination: &querytypes.PageRequest{
 				Limit:      1000,
@@ -243,10 +386,13 @@ func addCustomDenomMetadata(cfgDenom types.DenomMetadata, denomsMetadata map[str
 	}
 }
 
-func getMintDenom(grpcConn *grpc.ClientConn) (string, error) {
+func getMintDenom(ctx context.Context, grpcConn *grpc.ClientConn) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
 	mintClient := minttypes.NewQueryClient(grpcConn)
 	mintParamsRes, err := mintClient.Params(
-		context.Background(),
+		ctx,
 		&minttypes.QueryParamsRequest{},
 	)
 
@@ -265,10 +411,13 @@ func getMintDenom(grpcConn *grpc.ClientConn) (string, error) {
 	return "", err
 }
 
-func getBondDenom(grpcConn *grpc.ClientConn) (string, error) {
+func getBondDenom(ctx context.Context, grpcConn *grpc.ClientConn) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
 	stakingClient := stakingtypes.NewQueryClient(grpcConn)
 	stakingParamsRes, err := stakingClient.Params(
-		context.Background(),
+		ctx,
 		&stakingtypes.QueryParamsRequest{},
 	)
 	if err != nil {
@@ -279,12 +428,15 @@ func getBondDenom(grpcConn *grpc.ClientConn) (string, error) {
 }
 
 // Add legacy version of denomsMetadata function
-func addDenomsMetadataLegacy(grpcConn *grpc.ClientConn, denomsMetadata map[string]types.DenomMetadata) {
+func addDenomsMetadataLegacy(ctx context.Context, grpcConn *grpc.ClientConn, denomsMetadata map[string]types.DenomMetadata) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
 	bankClient := banktypes.NewQueryClient(grpcConn)
 
 	// Use the legacy v1beta1.PageRequest which is compatible with pre-v0.50.x
 	denomsRes, err := bankClient.DenomsMetadata(
-		context.Background(),
+		ctx,
 		&banktypes.QueryDenomsMetadataRequest{
 			Pagination: &querytypes.PageRequest{
 				Limit: 1000,
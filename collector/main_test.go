@@ -0,0 +1,60 @@
+package collector
+
+import (
+	"context"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+func TestKeepHistories(t *testing.T) {
+	delegation := func(delegator string, amount int64) stakingtypes.DelegationResponse {
+		return stakingtypes.DelegationResponse{
+			Delegation: stakingtypes.Delegation{DelegatorAddress: delegator, ValidatorAddress: "val1"},
+			Balance:    sdk.NewCoin("uatom", sdkmath.NewInt(amount)),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		keep       bool
+		wantInflow bool
+	}{
+		{"histories kept", true, true},
+		{"histories started over", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			staking := &fakeStaking{delegations: []stakingtypes.DelegationResponse{delegation("del1", 1_000_000)}}
+			conn := newTestConn(t, func(server *grpc.Server) {
+				stakingtypes.RegisterQueryServer(server, staking)
+			})
+			previous := newTestCollector(conn, "val1")
+			defer DeleteChainSeries(previous.chainID)
+			if err := previous.CollectValidatorDelegators(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			staking.delegations = append(staking.delegations, delegation("del2", 2_000_000))
+			collector := newTestCollector(conn, "val1")
+			if tt.keep {
+				collector.KeepHistories(previous)
+			}
+			if err := collector.CollectValidatorDelegators(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			inflow, found := gaugeValue(t, ValidatorDelegationInflowGauge, prometheus.Labels{"validator_address": "val1"})
+			if found != tt.wantInflow {
+				t.Fatalf("inflow exported = %v, want %v", found, tt.wantInflow)
+			}
+			if found && inflow != 2 {
+				t.Errorf("inflow = %v, want 2", inflow)
+			}
+		})
+	}
+}
@@ -16,41 +16,29 @@ var (
 	VotedActiveProposalGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "tendermint_active_proposals_vote_status",
-			Help: "Voter_address's vote status, return 1 if voted, return 0 if not voted",
+			Help: "Vote status of voter_address on an active proposal, 1 if voted, 0 if not voted",
 		},
-		[]string{"chain_id", "voter_address", "proposal_id"},
+		[]string{"chain_id", "voter_address", "proposal_id", "alias", "owner", "team"},
 	)
 
-	AvailableBalanceGauge = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "tendermint_available_balance",
-			Help: "Available balance",
-		},
-		[]string{"chain_id", "address", "denom"},
-	)
+	AvailableBalanceGauge = newAvailableBalanceGauge()
 
 	DelegatorRewardGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "tendermint_staking_reward_total",
 			Help: "Rewards of the delegator address from validator",
 		},
-		[]string{"delegator_address", "validator_address", "chain_id", "denom"},
+		[]string{"delegator_address", "validator_address", "chain_id", "denom", "alias", "owner", "team"},
 	)
 
-	DelegatorStakeGauge = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "tendermint_staking_total",
-			Help: "Stake amount of delegator address to validator",
-		},
-		[]string{"delegator_address", "validator_address", "chain_id", "denom"},
-	)
+	DelegatorStakeGauge = newDelegatorStakeGauge()
 
 	ValidatorCommissionGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "tendermint_validator_commission_total",
 			Help: "Commission of the validator",
 		},
-		[]string{"validator_address", "chain_id", "denom"},
+		[]string{"validator_address", "chain_id", "denom", "moniker"},
 	)
 
 	ValidatorDelegationGauge = prometheus.NewGaugeVec(
@@ -58,32 +46,14 @@ var (
 			Name: "tendermint_validator_delegators_total",
 			Help: "Number of delegators to the validator",
 		},
-		[]string{"validator_address", "chain_id"},
+		[]string{"validator_address", "chain_id", "moniker"},
 	)
 
-	ValidatorJailStatusGauge = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "tendermint_validator_jailed",
-			Help: "Return 1 if the validator is jailed",
-		},
-		[]string{"validator_address", "chain_id"},
-	)
+	ValidatorJailStatusGauge = newValidatorJailStatusGauge()
 
-	ValidatorCommissionRateGauge = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "tendermint_validator_commission_rate",
-			Help: "Commission rate of the validator",
-		},
-		[]string{"validator_address", "chain_id"},
-	)
+	ValidatorCommissionRateGauge = newValidatorCommissionRateGauge()
 
-	ValidatorVotingPowerGauge = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "tendermint_validator_voting_power_total",
-			Help: "Voting power of the validator",
-		},
-		[]string{"validator_address", "chain_id", "denom"},
-	)
+	ValidatorVotingPowerGauge = newValidatorVotingPowerGauge()
 
 	VotingPowerGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -96,14 +66,15 @@ var (
 	ValidatorVotingPowerRanking = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "tendermint_validator_voting_power_ranking",
-			Help: "Ranking of the validator based on voting power",
+			Help: "Ranking of the validator by tokens among all the validators",
 		},
-		[]string{"validator_address", "chain_id"},
+		[]string{"validator_address", "chain_id", "moniker"},
 	)
 
 	BondedTokenGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "tendermint_bonded_token",
+			Help: "Total token staked in bonded validators",
 		},
 		[]string{"chain_id"},
 	)
@@ -119,7 +90,7 @@ var (
 	CirculatingSupply = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "tendermint_circulating_supply",
-			Help: "total circulating supply of staking token",
+			Help: "Total circulating supply of staking token",
 		},
 		[]string{"chain_id"},
 	)
@@ -127,7 +98,7 @@ var (
 	InflationRate = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "tendermint_inflation_rate",
-			Help: "Current minting inflation value",
+			Help: "Current minting inflation rate",
 		},
 		[]string{"chain_id"},
 	)
@@ -135,6 +106,7 @@ var (
 	CommunityTax = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "tendermint_community_tax_rate",
+			Help: "Community tax rate of the distribution module",
 		},
 		[]string{"chain_id"},
 	)
@@ -142,11 +114,435 @@ var (
 	UnbondingTime = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "tendermint_unbonding_time",
-			Help: "Unbonding time in second",
+			Help: "Unbonding time in seconds",
+		},
+		[]string{"chain_id"},
+	)
+
+	MaxValidatorsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_max_validators",
+			Help: "Maximum number of validators in the active set",
+		},
+		[]string{"chain_id"},
+	)
+
+	ActiveSetCutoffTokensGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_active_set_cutoff_tokens",
+			Help: "Tokens of the last validator in the active set",
+		},
+		[]string{"chain_id", "denom"},
+	)
+
+	ValidatorBondedRankGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_bonded_rank",
+			Help: "Rank of the validator among bonded validators by tokens, 0 when not bonded",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorCutoffGapGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_cutoff_gap_tokens",
+			Help: "Tokens of the validator minus the tokens of the last validator in the active set",
+		},
+		[]string{"validator_address", "chain_id", "denom", "moniker"},
+	)
+
+	ValidatorVotingPowerShareGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_voting_power_ratio",
+			Help: "Share of the total bonded tokens held by the validator",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorsCountGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validators",
+			Help: "Number of validators by bond status",
+		},
+		[]string{"chain_id", "status"},
+	)
+
+	JailedValidatorsCountGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_jailed_validators",
+			Help: "Number of jailed validators",
+		},
+		[]string{"chain_id"},
+	)
+
+	NakamotoCoefficientGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_nakamoto_coefficient",
+			Help: "Smallest number of bonded validators holding more than the threshold share of the bonded tokens",
+		},
+		[]string{"chain_id", "threshold"},
+	)
+
+	VotingPowerGiniGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_voting_power_gini",
+			Help: "Gini coefficient of the tokens of bonded validators",
 		},
 		[]string{"chain_id"},
 	)
 
+	TopValidatorsShareGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_top_validators_voting_power_ratio",
+			Help: "Share of the bonded tokens held by the top validators",
+		},
+		[]string{"chain_id", "top"},
+	)
+
+	SetValidatorJailedGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_set_validator_jailed",
+			Help: "Return 1 if the validator of the exported validator set is jailed",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	SetValidatorStatusGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_set_validator_status",
+			Help: "Bond status of the validator of the exported validator set, 1 unbonded, 2 unbonding, 3 bonded",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	SetValidatorTokensGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_set_validator_tokens",
+			Help: "Tokens of the validator of the exported validator set",
+		},
+		[]string{"validator_address", "chain_id", "denom", "moniker"},
+	)
+
+	SetValidatorCommissionRateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_set_validator_commission_ratio",
+			Help: "Commission rate of the validator of the exported validator set",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorConsensusPowerGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_consensus_power",
+			Help: "Consensus power of the validator, its tokens divided by the power reduction",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorCometBFTPowerGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_cometbft_voting_power",
+			Help: "Voting power of the validator in the CometBFT validator set, 0 when not in the set",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorPowerMismatchGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_voting_power_mismatch",
+			Help: "Return 1 if the consensus power of a bonded validator differs from its CometBFT voting power",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorStatusGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_status",
+			Help: "Bond status of the validator, 1 unbonded, 2 unbonding, 3 bonded",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorMinSelfDelegationGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_min_self_delegation_tokens",
+			Help: "Minimum self-delegation of the validator",
+		},
+		[]string{"validator_address", "chain_id", "denom", "moniker"},
+	)
+
+	ValidatorSelfDelegationGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_self_delegation_tokens",
+			Help: "Tokens delegated to the validator by its operator account",
+		},
+		[]string{"validator_address", "chain_id", "denom", "moniker"},
+	)
+
+	ValidatorSelfDelegationNearMinimumGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_self_delegation_near_minimum",
+			Help: "Return 1 if the self-delegation is less than validator.self_delegation_margin above the minimum",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorCommissionMaxRateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_commission_max_ratio",
+			Help: "Maximum commission rate of the validator",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorCommissionMaxChangeRateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_commission_max_change_ratio",
+			Help: "Maximum daily change of the commission rate of the validator",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorCommissionUpdateTimeGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_commission_update_timestamp_seconds",
+			Help: "Unix time of the last commission change of the validator",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorUnbondingHeightGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_unbonding_height",
+			Help: "Height at which the validator started unbonding",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorUnbondingTimeGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_unbonding_timestamp_seconds",
+			Help: "Unix time at which the validator completes unbonding, 0 when not unbonding",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorOutstandingRewardsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_distribution_validator_outstanding_rewards_tokens",
+			Help: "Rewards of the validator not withdrawn yet, including its commission",
+		},
+		[]string{"validator_address", "chain_id", "denom", "moniker"},
+	)
+
+	ValidatorSlashesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_distribution_validator_slashes",
+			Help: "Number of slashes of the validator within the slash window",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorSlashedRatioGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_distribution_validator_slashed_ratio",
+			Help: "Share of the stake of the validator slashed within the slash window",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorLastSlashRatioGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_distribution_validator_last_slash_ratio",
+			Help: "Fraction of the last slash of the validator within the slash window, 0 without slashes",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	CommunityPoolGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_distribution_community_pool_tokens",
+			Help: "Balance of the community pool",
+		},
+		[]string{"chain_id", "denom"},
+	)
+
+	AnnualProvisionsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_mint_annual_provisions_tokens",
+			Help: "Tokens minted per year, exported when the inflation ratio is not available",
+		},
+		[]string{"chain_id", "denom"},
+	)
+
+	ValidatorTopDelegatorTokensGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_top_delegator_tokens",
+			Help: "Tokens delegated to the validator by its largest delegators",
+		},
+		[]string{"validator_address", "chain_id", "denom", "moniker", "delegator_address"},
+	)
+
+	ValidatorTopDelegatorRankGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_top_delegator_rank",
+			Help: "Rank of the delegator among the largest delegators of the validator, 1 for the largest",
+		},
+		[]string{"validator_address", "chain_id", "moniker", "delegator_address"},
+	)
+
+	ValidatorTopDelegatorsShareGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_top_delegators_ratio",
+			Help: "Share of the tokens delegated to the validator held by its largest delegators",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorDelegationInflowGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_delegation_inflow_tokens",
+			Help: "Tokens delegated to the validator since the previous collection",
+		},
+		[]string{"validator_address", "chain_id", "denom", "moniker"},
+	)
+
+	ValidatorDelegationOutflowGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_delegation_outflow_tokens",
+			Help: "Tokens undelegated or redelegated from the validator since the previous collection",
+		},
+		[]string{"validator_address", "chain_id", "denom", "moniker"},
+	)
+
+	ValidatorNewDelegatorsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_new_delegators",
+			Help: "Delegators of the validator which were not delegating at the previous collection",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	ValidatorLostDelegatorsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_staking_validator_lost_delegators",
+			Help: "Delegators of the validator at the previous collection which are not delegating anymore",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+
+	VestingOriginalGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_auth_vesting_original_tokens",
+			Help: "Tokens vesting at the creation of the vesting account",
+		},
+		[]string{"chain_id", "address", "denom", "alias", "owner", "team"},
+	)
+
+	VestingVestedGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_auth_vesting_vested_tokens",
+			Help: "Tokens of the vesting account already vested",
+		},
+		[]string{"chain_id", "address", "denom", "alias", "owner", "team"},
+	)
+
+	VestingLockedGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_auth_vesting_locked_tokens",
+			Help: "Tokens of the vesting account not vested yet, including delegated ones",
+		},
+		[]string{"chain_id", "address", "denom", "alias", "owner", "team"},
+	)
+
+	VestingDelegatedGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_auth_vesting_delegated_tokens",
+			Help: "Tokens of the vesting account delegated while not vested",
+		},
+		[]string{"chain_id", "address", "denom", "alias", "owner", "team"},
+	)
+
+	VestingNextUnlockGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_auth_vesting_next_unlock_timestamp_seconds",
+			Help: "Unix time at which tokens of the vesting account vest next, 0 once fully vested",
+		},
+		[]string{"chain_id", "address", "alias", "owner", "team"},
+	)
+
+	SpendableBalanceGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_bank_spendable_balance_tokens",
+			Help: "Balance of the vesting account which can be spent",
+		},
+		[]string{"chain_id", "address", "denom", "alias", "owner", "team"},
+	)
+
+	AccountNumberGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_auth_account_number",
+			Help: "Account number of the address",
+		},
+		[]string{"chain_id", "address", "alias", "owner", "team"},
+	)
+
+	AccountSequenceGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_auth_account_sequence",
+			Help: "Sequence of the account, the number of transactions it sent",
+		},
+		[]string{"chain_id", "address", "alias", "owner", "team"},
+	)
+
+	AccountSequenceRateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_auth_account_sequence_rate",
+			Help: "Increase of the sequence of the account per second since the previous collection",
+		},
+		[]string{"chain_id", "address", "alias", "owner", "team"},
+	)
+
+	AccountSequenceUnchangedGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_auth_account_sequence_unchanged_seconds",
+			Help: "Seconds since the sequence of the account last changed, counted from the exporter start at most",
+		},
+		[]string{"chain_id", "address", "alias", "owner", "team"},
+	)
+
+	BalanceThresholdGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_bank_balance_threshold_tokens",
+			Help: "Minimum balance configured for the address",
+		},
+		[]string{"chain_id", "address", "denom", "alias", "owner", "team"},
+	)
+
+	BalanceBelowThresholdGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_bank_balance_below_threshold",
+			Help: "Return 1 if the balance of the address is below its threshold",
+		},
+		[]string{"chain_id", "address", "denom", "alias", "owner", "team"},
+	)
+
+	BalanceSpendRateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_bank_balance_spend_rate",
+			Help: "Balance spent by the address per second over the balances window, top-ups excluded",
+		},
+		[]string{"chain_id", "address", "denom", "alias", "owner", "team"},
+	)
+
+	BalanceDepletionGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_bank_balance_depletion_seconds",
+			Help: "Seconds until the balance of the address is spent at the current spend rate, 0 once empty and +Inf when not spending",
+		},
+		[]string{"chain_id", "address", "denom", "alias", "owner", "team"},
+	)
+
 	// represents number of errors while collecting chain stats
 	// collector label is used to determine which collector to debug
 	ErrorGauge = prometheus.NewCounterVec(
@@ -156,6 +552,89 @@ var (
 		},
 		[]string{"collector"},
 	)
+
+	GRPCRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "cosmos_exporter_grpc_request_duration_seconds",
+			Help:    "Duration of the gRPC queries made to the node",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method", "code"},
+	)
+
+	GRPCRequestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cosmos_exporter_grpc_request_errors_total",
+			Help: "Total gRPC queries made to the node that returned an error",
+		},
+		[]string{"method", "code"},
+	)
+
+	RPCRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "cosmos_exporter_rpc_request_duration_seconds",
+			Help:    "Duration of the CometBFT RPC calls made to the node",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method", "result"},
+	)
+
+	RPCRequestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cosmos_exporter_rpc_request_errors_total",
+			Help: "Total CometBFT RPC calls made to the node that returned an error",
+		},
+		[]string{"method"},
+	)
+
+	GRPCRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cosmos_exporter_grpc_retries_total",
+			Help: "Total gRPC queries retried after a retryable error",
+		},
+		[]string{"method"},
+	)
+
+	// 0 is closed, 1 is open and 2 is half open
+	CircuitBreakerStateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_exporter_circuit_breaker_state",
+			Help: "State of the circuit breaker of the endpoint, 0 closed, 1 open, 2 half open",
+		},
+		[]string{"endpoint"},
+	)
+
+	CircuitBreakerTrips = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cosmos_exporter_circuit_breaker_trips_total",
+			Help: "Total times the circuit breaker of the endpoint opened",
+		},
+		[]string{"endpoint"},
+	)
+
+	CollectorDurationGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_exporter_collector_duration_seconds",
+			Help: "Duration of the last run of the collector",
+		},
+		[]string{"collector"},
+	)
+
+	ValidatorSetCappedGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_exporter_validator_set_capped_validators",
+			Help: "Validators of the validator set export dropped because the cap was reached",
+		},
+		[]string{"chain_id"},
+	)
+
+	CollectorLastSuccessGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_exporter_collector_last_success_timestamp_seconds",
+			Help: "Unix time of the last run of the collector without errors",
+		},
+		[]string{"collector"},
+	)
 )
 
 func init() {
@@ -178,6 +657,121 @@ func init() {
 		InflationRate,
 		CommunityTax,
 		UnbondingTime,
+		MaxValidatorsGauge,
+		ActiveSetCutoffTokensGauge,
+		ValidatorBondedRankGauge,
+		ValidatorCutoffGapGauge,
+		ValidatorVotingPowerShareGauge,
+		ValidatorsCountGauge,
+		JailedValidatorsCountGauge,
+		NakamotoCoefficientGauge,
+		VotingPowerGiniGauge,
+		TopValidatorsShareGauge,
+		SetValidatorJailedGauge,
+		SetValidatorStatusGauge,
+		SetValidatorTokensGauge,
+		SetValidatorCommissionRateGauge,
+		ValidatorConsensusPowerGauge,
+		ValidatorCometBFTPowerGauge,
+		ValidatorPowerMismatchGauge,
+		ValidatorStatusGauge,
+		ValidatorMinSelfDelegationGauge,
+		ValidatorSelfDelegationGauge,
+		ValidatorSelfDelegationNearMinimumGauge,
+		ValidatorCommissionMaxRateGauge,
+		ValidatorCommissionMaxChangeRateGauge,
+		ValidatorCommissionUpdateTimeGauge,
+		ValidatorUnbondingHeightGauge,
+		ValidatorUnbondingTimeGauge,
+		ValidatorOutstandingRewardsGauge,
+		ValidatorSlashesGauge,
+		ValidatorSlashedRatioGauge,
+		ValidatorLastSlashRatioGauge,
+		CommunityPoolGauge,
+		AnnualProvisionsGauge,
+		ValidatorTopDelegatorTokensGauge,
+		ValidatorTopDelegatorRankGauge,
+		ValidatorTopDelegatorsShareGauge,
+		ValidatorDelegationInflowGauge,
+		ValidatorDelegationOutflowGauge,
+		ValidatorNewDelegatorsGauge,
+		ValidatorLostDelegatorsGauge,
+		VestingOriginalGauge,
+		VestingVestedGauge,
+		VestingLockedGauge,
+		VestingDelegatedGauge,
+		VestingNextUnlockGauge,
+		SpendableBalanceGauge,
+		AccountNumberGauge,
+		AccountSequenceGauge,
+		AccountSequenceRateGauge,
+		AccountSequenceUnchangedGauge,
+		BalanceThresholdGauge,
+		BalanceBelowThresholdGauge,
+		BalanceSpendRateGauge,
+		BalanceDepletionGauge,
 		ErrorGauge,
+		GRPCRequestDuration,
+		GRPCRequestErrors,
+		RPCRequestDuration,
+		RPCRequestErrors,
+		GRPCRetries,
+		CircuitBreakerStateGauge,
+		CircuitBreakerTrips,
+		CollectorDurationGauge,
+		CollectorLastSuccessGauge,
+		ValidatorSetCappedGauge,
+	)
+}
+
+// The gauges below are also registered on the registry of each /probe request
+
+func newAvailableBalanceGauge() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_available_balance",
+			Help: "Available balance",
+		},
+		[]string{"chain_id", "address", "denom", "alias", "owner", "team"},
+	)
+}
+
+func newDelegatorStakeGauge() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_staking_total",
+			Help: "Stake amount of delegator address to validator",
+		},
+		[]string{"delegator_address", "validator_address", "chain_id", "denom", "alias", "owner", "team"},
+	)
+}
+
+func newValidatorJailStatusGauge() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_validator_jailed",
+			Help: "Return 1 if the validator is jailed",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+}
+
+func newValidatorCommissionRateGauge() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_validator_commission_rate",
+			Help: "Commission rate of the validator",
+		},
+		[]string{"validator_address", "chain_id", "moniker"},
+	)
+}
+
+func newValidatorVotingPowerGauge() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tendermint_validator_voting_power_total",
+			Help: "Voting power of the validator",
+		},
+		[]string{"validator_address", "chain_id", "denom", "moniker"},
 	)
 }
@@ -0,0 +1,151 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Naming modes selecting which names the chain metrics are exported with
+const (
+	NamingLegacy = "legacy"
+	NamingNew    = "new"
+	NamingBoth   = "both"
+)
+
+// metricNames maps the legacy tendermint_* names to the cosmos_* names, which
+// are prefixed with the module the value comes from and use base unit
+// suffixes, and the legacy error counter name to a _total one. Metrics added
+// after the rename only have a cosmos_* name.
+var metricNames = map[string]string{
+	"tendermint_active_proposals_total":         "cosmos_gov_active_proposals",
+	"tendermint_active_proposals_vote_status":   "cosmos_gov_active_proposal_voted",
+	"tendermint_available_balance":              "cosmos_bank_balance_tokens",
+	"tendermint_circulating_supply":             "cosmos_bank_circulating_supply_tokens",
+	"tendermint_staking_reward_total":           "cosmos_distribution_delegator_rewards_tokens",
+	"tendermint_validator_commission_total":     "cosmos_distribution_validator_commission_tokens",
+	"tendermint_community_tax_rate":             "cosmos_distribution_community_tax_ratio",
+	"tendermint_inflation_rate":                 "cosmos_mint_inflation_ratio",
+	"tendermint_staking_total":                  "cosmos_staking_delegation_tokens",
+	"tendermint_validator_delegators_total":     "cosmos_staking_validator_delegators",
+	"tendermint_validator_jailed":               "cosmos_staking_validator_jailed",
+	"tendermint_validator_commission_rate":      "cosmos_staking_validator_commission_ratio",
+	"tendermint_validator_voting_power_total":   "cosmos_staking_validator_voting_power_tokens",
+	"tendermint_validator_voting_power_ranking": "cosmos_staking_validator_rank",
+	"tendermint_voting_power_total":             "cosmos_staking_voting_power_tokens",
+	"tendermint_bonded_token":                   "cosmos_staking_bonded_tokens",
+	"tendermint_not_bonded_token":               "cosmos_staking_not_bonded_tokens",
+	"tendermint_unbonding_time":                 "cosmos_staking_unbonding_time_seconds",
+	"cosmos_exporter_error_count":               "cosmos_exporter_errors_total",
+}
+
+// legacyOnly holds the series only exported under their legacy name, whose
+// value does not match the new one, e.g. the annual provisions which
+// tendermint_inflation_rate held on chains without an inflation query, by
+// legacy name and labels
+var legacyOnly = struct {
+	sync.RWMutex
+	series map[string]map[string]bool
+}{series: make(map[string]map[string]bool)}
+
+// setLegacyOnly marks the series of the legacy metric name with labels as
+// only exported under that name, or unmarks it
+func setLegacyOnly(name string, labels prometheus.Labels, only bool) {
+	legacyOnly.Lock()
+	defer legacyOnly.Unlock()
+
+	key := labelsKey(labels)
+	if !only {
+		delete(legacyOnly.series[name], key)
+		return
+	}
+	if legacyOnly.series[name] == nil {
+		legacyOnly.series[name] = make(map[string]bool)
+	}
+	legacyOnly.series[name][key] = true
+}
+
+// isLegacyOnly returns true if the series metric of the legacy metric name is
+// only exported under that name
+func isLegacyOnly(name string, metric *dto.Metric) bool {
+	legacyOnly.RLock()
+	defer legacyOnly.RUnlock()
+
+	if len(legacyOnly.series[name]) == 0 {
+		return false
+	}
+	labels := make(prometheus.Labels, len(metric.Label))
+	for _, label := range metric.Label {
+		labels[label.GetName()] = label.GetValue()
+	}
+	return legacyOnly.series[name][labelsKey(labels)]
+}
+
+// labelsKey identifies a series by its labels, in any order
+func labelsKey(labels prometheus.Labels) string {
+	pairs := make([]string, 0, len(labels))
+	for name, value := range labels {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\x00")
+}
+
+func validateNaming(naming string) error {
+	switch naming {
+	case "", NamingLegacy, NamingNew, NamingBoth:
+		return nil
+	}
+	return fmt.Errorf("invalid naming %q, expected one of %s, %s, %s", naming, NamingLegacy, NamingNew, NamingBoth)
+}
+
+// MetricNames returns the names the metric registered as name is exported
+// under with naming
+func MetricNames(name, naming string) []string {
+	newName, found := metricNames[name]
+	if !found {
+		return []string{name}
+	}
+	switch naming {
+	case NamingNew:
+		return []string{newName}
+	case NamingBoth:
+		return []string{name, newName}
+	default:
+		return []string{name}
+	}
+}
+
+// rename returns family under the names selected by naming, sharing its
+// metrics but the legacy only ones, which are left out of the new name
+func rename(family *dto.MetricFamily, naming string) []*dto.MetricFamily {
+	names := MetricNames(family.GetName(), naming)
+	families := make([]*dto.MetricFamily, 0, len(names))
+	for _, name := range names {
+		if name == family.GetName() {
+			families = append(families, family)
+			continue
+		}
+
+		metrics := make([]*dto.Metric, 0, len(family.Metric))
+		for _, metric := range family.Metric {
+			if !isLegacyOnly(family.GetName(), metric) {
+				metrics = append(metrics, metric)
+			}
+		}
+		if len(metrics) == 0 {
+			continue
+		}
+		families = append(families, &dto.MetricFamily{
+			Name:   proto(name),
+			Help:   family.Help,
+			Type:   family.Type,
+			Metric: metrics,
+		})
+	}
+	return families
+}
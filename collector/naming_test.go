@@ -0,0 +1,31 @@
+package collector
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMetricNames(t *testing.T) {
+	tests := []struct {
+		name   string
+		metric string
+		naming string
+		want   []string
+	}{
+		{"legacy by default", "tendermint_inflation_rate", "", []string{"tendermint_inflation_rate"}},
+		{"legacy", "tendermint_inflation_rate", NamingLegacy, []string{"tendermint_inflation_rate"}},
+		{"new", "tendermint_inflation_rate", NamingNew, []string{"cosmos_mint_inflation_ratio"}},
+		{"both", "tendermint_inflation_rate", NamingBoth, []string{"tendermint_inflation_rate", "cosmos_mint_inflation_ratio"}},
+		{"legacy error counter", "cosmos_exporter_error_count", NamingLegacy, []string{"cosmos_exporter_error_count"}},
+		{"new error counter", "cosmos_exporter_error_count", NamingNew, []string{"cosmos_exporter_errors_total"}},
+		{"both error counters", "cosmos_exporter_error_count", NamingBoth, []string{"cosmos_exporter_error_count", "cosmos_exporter_errors_total"}},
+		{"metric without a legacy name", "cosmos_staking_max_validators", NamingBoth, []string{"cosmos_staking_max_validators"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MetricNames(tt.metric, tt.naming); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MetricNames(%q, %q) = %v, want %v", tt.metric, tt.naming, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	cmthttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	types "github.com/forbole/cosmos-exporter/types"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestConn serves the query servers registered by register in memory and
+// returns a connection to them, dialed with opts
+func newTestConn(t *testing.T, register func(*grpc.Server), opts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	// Like a node, the server encodes the responses with the SDK codec
+	server := grpc.NewServer(grpc.ForceServerCodec(sdkCodec()))
+	register(server)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	opts = append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, opts...)
+	conn, err := grpc.Dial("bufconn", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// sdkCodec returns the gRPC codec of the Cosmos SDK, which encodes the
+// custom types such as math.LegacyDec the default codec does not handle
+func sdkCodec() encoding.Codec {
+	return codec.NewProtoCodec(codectypes.NewInterfaceRegistry()).GRPCCodec()
+}
+
+// newTestRPC serves the CometBFT RPC results of the methods of results, given
+// in JSON, and returns a client of that server
+func newTestRPC(t *testing.T, results map[string]string) *cmthttp.HTTP {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, found := results[req.Method]
+		if !found {
+			http.Error(w, "unknown method "+req.Method, http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  json.RawMessage(result),
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := cmthttp.New(server.URL, "/websocket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// testStatus is the CometBFT status of the chain test-1 at height 100
+const testStatus = `{
+	"node_info": {"protocol_version": {"p2p": "8", "block": "11", "app": "0"}, "network": "test-1", "other": {}},
+	"sync_info": {"latest_block_height": "100", "latest_block_time": "2026-01-01T00:00:00Z", "earliest_block_height": "1", "earliest_block_time": "2025-01-01T00:00:00Z", "catching_up": false},
+	"validator_info": {"voting_power": "0"}
+}`
+
+// newTestCollector returns a collector of the chain test-1 whose bond denom
+// is uatom, displayed as atom with 6 decimals
+func newTestCollector(conn *grpc.ClientConn, valAddress string) *CosmosSDKCollector {
+	return &CosmosSDKCollector{
+		grpcConn:         conn,
+		chainID:          "test-1",
+		valAddress:       valAddress,
+		defaultBondDenom: "uatom",
+		defaultMintDenom: "uatom",
+		denomMetadata: map[string]types.DenomMetadata{
+			"uatom": types.NewDenomMetadata("uatom", "atom", 6),
+		},
+		delegations:    &delegationHistory{},
+		accounts:       &accountHistory{},
+		balanceHistory: &balanceHistory{},
+	}
+}
+
+// gaugeValue returns the value of the series of gauge having the given
+// labels, and false when none is exported
+func gaugeValue(t *testing.T, gauge *prometheus.GaugeVec, labels prometheus.Labels) (float64, bool) {
+	t.Helper()
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		gauge.Collect(ch)
+		close(ch)
+	}()
+
+	var value float64
+	var found bool
+	for metric := range ch {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			t.Fatal(err)
+		}
+		matched := 0
+		for _, label := range m.Label {
+			if want, ok := labels[label.GetName()]; ok && want == label.GetValue() {
+				matched++
+			}
+		}
+		if matched == len(labels) {
+			value, found = m.GetGauge().GetValue(), true
+		}
+	}
+	return value, found
+}
+
+// labelValues returns the sorted values of the label name of the series of gauge
+func labelValues(t *testing.T, gauge *prometheus.GaugeVec, name string) []string {
+	t.Helper()
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		gauge.Collect(ch)
+		close(ch)
+	}()
+
+	values := []string{}
+	for metric := range ch {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			t.Fatal(err)
+		}
+		for _, label := range m.Label {
+			if label.GetName() == name {
+				values = append(values, label.GetValue())
+			}
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// fakeStaking answers the staking queries from a fixed validator set and
+// the delegations to the validators
+type fakeStaking struct {
+	stakingtypes.UnimplementedQueryServer
+	params      stakingtypes.Params
+	validators  []stakingtypes.Validator
+	delegations []stakingtypes.DelegationResponse
+}
+
+func (s *fakeStaking) Params(ctx context.Context, req *stakingtypes.QueryParamsRequest) (*stakingtypes.QueryParamsResponse, error) {
+	return &stakingtypes.QueryParamsResponse{Params: s.params}, nil
+}
+
+func (s *fakeStaking) Validators(ctx context.Context, req *stakingtypes.QueryValidatorsRequest) (*stakingtypes.QueryValidatorsResponse, error) {
+	res := &stakingtypes.QueryValidatorsResponse{}
+	for _, validator := range s.validators {
+		if req.Status == "" || validator.Status.String() == req.Status {
+			res.Validators = append(res.Validators, validator)
+		}
+	}
+	return res, nil
+}
+
+func (s *fakeStaking) Validator(ctx context.Context, req *stakingtypes.QueryValidatorRequest) (*stakingtypes.QueryValidatorResponse, error) {
+	for _, validator := range s.validators {
+		if validator.OperatorAddress == req.ValidatorAddr {
+			return &stakingtypes.QueryValidatorResponse{Validator: validator}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "validator %s not found", req.ValidatorAddr)
+}
+
+func (s *fakeStaking) ValidatorDelegations(ctx context.Context, req *stakingtypes.QueryValidatorDelegationsRequest) (*stakingtypes.QueryValidatorDelegationsResponse, error) {
+	res := &stakingtypes.QueryValidatorDelegationsResponse{}
+	for _, delegation := range s.delegations {
+		if delegation.Delegation.ValidatorAddress == req.ValidatorAddr {
+			res.DelegationResponses = append(res.DelegationResponses, delegation)
+		}
+	}
+	return res, nil
+}
+
+// fakeDistribution answers the distribution queries from fixed rewards,
+// slashes and community pool, and records the last slashes request
+type fakeDistribution struct {
+	distributiontypes.UnimplementedQueryServer
+	rewards sdk.DecCoins
+	slashes []distributiontypes.ValidatorSlashEvent
+	pool    sdk.DecCoins
+
+	mu             sync.Mutex
+	slashesRequest *distributiontypes.QueryValidatorSlashesRequest
+}
+
+func (s *fakeDistribution) ValidatorOutstandingRewards(ctx context.Context, req *distributiontypes.QueryValidatorOutstandingRewardsRequest) (*distributiontypes.QueryValidatorOutstandingRewardsResponse, error) {
+	return &distributiontypes.QueryValidatorOutstandingRewardsResponse{
+		Rewards: distributiontypes.ValidatorOutstandingRewards{Rewards: s.rewards},
+	}, nil
+}
+
+func (s *fakeDistribution) ValidatorSlashes(ctx context.Context, req *distributiontypes.QueryValidatorSlashesRequest) (*distributiontypes.QueryValidatorSlashesResponse, error) {
+	s.mu.Lock()
+	s.slashesRequest = req
+	s.mu.Unlock()
+	return &distributiontypes.QueryValidatorSlashesResponse{Slashes: s.slashes}, nil
+}
+
+func (s *fakeDistribution) CommunityPool(ctx context.Context, req *distributiontypes.QueryCommunityPoolRequest) (*distributiontypes.QueryCommunityPoolResponse, error) {
+	return &distributiontypes.QueryCommunityPoolResponse{Pool: s.pool}, nil
+}
+
+// testValidator returns a validator holding tokens uatom
+func testValidator(operator string, status stakingtypes.BondStatus, tokens int64) stakingtypes.Validator {
+	return stakingtypes.Validator{
+		OperatorAddress:   operator,
+		Status:            status,
+		Tokens:            sdkmath.NewInt(tokens),
+		DelegatorShares:   sdkmath.LegacyNewDec(tokens),
+		MinSelfDelegation: sdkmath.OneInt(),
+		Description:       stakingtypes.Description{Moniker: operator + "-moniker"},
+		Commission: stakingtypes.Commission{
+			CommissionRates: stakingtypes.NewCommissionRates(sdkmath.LegacyNewDecWithPrec(5, 2), sdkmath.LegacyNewDecWithPrec(2, 1), sdkmath.LegacyNewDecWithPrec(1, 2)),
+		},
+	}
+}
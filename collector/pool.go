@@ -0,0 +1,101 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"google.golang.org/grpc"
+)
+
+// LimitConcurrency returns a gRPC interceptor allowing at most limit queries
+// in flight at the same time on the connection
+func LimitConcurrency(limit int) grpc.UnaryClientInterceptor {
+	sem := make(chan struct{}, limit)
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		defer func() { <-sem }()
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// runPool runs every task with at most workers of them at the same time,
+// tasks which did not start yet are skipped once ctx is cancelled
+func runPool(ctx context.Context, workers int, tasks []func(context.Context)) {
+	queue := make(chan func(context.Context))
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range queue {
+				task(ctx)
+			}
+		}()
+	}
+
+	for _, task := range tasks {
+		select {
+		case queue <- task:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// addressErrors are the errors of the addresses for which fn failed in
+// forEachAddress, the other addresses succeeded
+type addressErrors struct {
+	addresses []string
+	errs      []error
+}
+
+func (e *addressErrors) Error() string {
+	return errors.Join(e.errs...).Error()
+}
+
+func (e *addressErrors) Unwrap() []error {
+	return e.errs
+}
+
+// forEachAddress calls fn for every monitored delegator address
+// with at most MaxRequests calls running at the same time,
+// it returns the errors of all the calls joined together
+func (collector *CosmosSDKCollector) forEachAddress(ctx context.Context, fn func(ctx context.Context, address string) error) error {
+	var mu sync.Mutex
+	failed := &addressErrors{}
+
+	addresses := collector.delegatorAddresses()
+	tasks := make([]func(context.Context), 0, len(addresses))
+	for _, address := range addresses {
+		address := address
+		tasks = append(tasks, func(ctx context.Context) {
+			if err := fn(ctx, address); err != nil {
+				mu.Lock()
+				failed.addresses = append(failed.addresses, address)
+				failed.errs = append(failed.errs, err)
+				mu.Unlock()
+			}
+		})
+	}
+	runPool(ctx, collector.concurrency.MaxRequests, tasks)
+
+	// Addresses skipped because of the cancellation have no error
+	if ctx.Err() != nil {
+		return errors.Join(append(failed.errs, ctx.Err())...)
+	}
+	if len(failed.errs) == 0 {
+		return nil
+	}
+	return failed
+}
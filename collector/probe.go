@@ -0,0 +1,69 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Probe modules accepted by the /probe endpoint
+const (
+	ProbeModuleBalance     = "balance"
+	ProbeModuleDelegations = "delegations"
+	ProbeModuleValidator   = "validator"
+)
+
+// Probe runs the collector logic of module for a single address and returns
+// a registry holding only the metrics of that target, together with
+// probe_success and probe_duration_seconds as in blackbox_exporter
+func (collector *CosmosSDKCollector) Probe(ctx context.Context, module, address string) (*prometheus.Registry, error) {
+	registry := prometheus.NewRegistry()
+
+	var probe func(ctx context.Context) error
+	switch module {
+	case ProbeModuleBalance:
+		gauge := newAvailableBalanceGauge()
+		registry.MustRegister(gauge)
+		probe = func(ctx context.Context) error {
+			_, err := collector.collectBalance(ctx, address, gauge)
+			return err
+		}
+	case ProbeModuleDelegations:
+		gauge := newDelegatorStakeGauge()
+		registry.MustRegister(gauge)
+		probe = func(ctx context.Context) error {
+			return collector.collectDelegations(ctx, address, gauge)
+		}
+	case ProbeModuleValidator:
+		gauges := validatorStatusGauges{
+			jailed:         newValidatorJailStatusGauge(),
+			commissionRate: newValidatorCommissionRateGauge(),
+			votingPower:    newValidatorVotingPowerGauge(),
+		}
+		registry.MustRegister(gauges.jailed, gauges.commissionRate, gauges.votingPower)
+		probe = func(ctx context.Context) error {
+			return collector.collectValidatorStatus(ctx, address, gauges)
+		}
+	default:
+		return nil, fmt.Errorf("unknown module %q, expected one of %s, %s, %s", module, ProbeModuleBalance, ProbeModuleDelegations, ProbeModuleValidator)
+	}
+
+	probeSuccess := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "probe_success",
+		Help: "Return 1 if the probe succeeded",
+	})
+	probeDuration := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "probe_duration_seconds",
+		Help: "Duration of the probe in seconds",
+	})
+	registry.MustRegister(probeSuccess, probeDuration)
+
+	start := time.Now()
+	if err := probe(ctx); err == nil {
+		probeSuccess.Set(1)
+	}
+	probeDuration.Set(time.Since(start).Seconds())
+	return registry, nil
+}
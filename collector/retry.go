@@ -0,0 +1,182 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	types "github.com/forbole/cosmos-exporter/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Circuit breaker states as exported by CircuitBreakerStateGauge
+const (
+	breakerClosed   = 0
+	breakerOpen     = 1
+	breakerHalfOpen = 2
+)
+
+// parseCode converts a gRPC status code name such as "Unavailable" or
+// "RESOURCE_EXHAUSTED" to its code
+func parseCode(name string) (codes.Code, error) {
+	normalized := strings.ReplaceAll(name, "_", "")
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		if strings.EqualFold(c.String(), normalized) {
+			return c, nil
+		}
+	}
+	return codes.Unknown, fmt.Errorf("unknown gRPC status code %q", name)
+}
+
+// Retry returns a gRPC interceptor retrying the queries failing with one of
+// the retryable codes, waiting an exponential backoff between two attempts
+func Retry(cfg types.Retry) (grpc.UnaryClientInterceptor, error) {
+	cfg = cfg.WithDefaults()
+
+	retryable := make(map[codes.Code]bool, len(cfg.RetryableCodes))
+	for _, name := range cfg.RetryableCodes {
+		code, err := parseCode(name)
+		if err != nil {
+			return nil, err
+		}
+		retryable[code] = true
+	}
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var err error
+		for attempt := 0; attempt < cfg.MaxAttempts; attempt++ {
+			if attempt > 0 {
+				timer := time.NewTimer(backoff(cfg, attempt))
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return err
+				case <-timer.C:
+				}
+				GRPCRetries.WithLabelValues(method).Inc()
+			}
+
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || !retryable[status.Code(err)] || errors.Is(err, errCircuitOpen) {
+				return err
+			}
+		}
+		return err
+	}, nil
+}
+
+// backoff returns the wait before the given retry attempt, the first one
+// being attempt 1, growing by Multiplier up to MaxBackoff
+func backoff(cfg types.Retry, attempt int) time.Duration {
+	wait := time.Duration(float64(cfg.InitialBackoff) * math.Pow(cfg.Multiplier, float64(attempt-1)))
+	if wait > cfg.MaxBackoff {
+		return cfg.MaxBackoff
+	}
+	return wait
+}
+
+var errCircuitOpen = status.Error(codes.Unavailable, "circuit breaker is open")
+
+// circuitBreaker stops sending queries to an endpoint after FailureThreshold
+// consecutive failures, and lets a single query through once OpenTimeout
+// elapsed to check whether the endpoint recovered
+type circuitBreaker struct {
+	mu       sync.Mutex
+	cfg      types.CircuitBreaker
+	endpoint string
+	state    int
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// CircuitBreaker returns a gRPC interceptor implementing a circuit breaker
+// for the given endpoint, its state is exported by CircuitBreakerStateGauge
+func CircuitBreaker(endpoint string, cfg types.CircuitBreaker) grpc.UnaryClientInterceptor {
+	breaker := &circuitBreaker{cfg: cfg.WithDefaults(), endpoint: endpoint}
+	breaker.setState(breakerClosed)
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !breaker.allow() {
+			return errCircuitOpen
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		breaker.record(err)
+		return err
+	}
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.cfg.OpenTimeout {
+			return false
+		}
+		b.setState(breakerHalfOpen)
+		b.probing = true
+		return true
+	case breakerHalfOpen:
+		// Only one query is let through while half open
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Only errors telling the endpoint is unhealthy count as failures,
+	// e.g. NotFound is a valid answer of a healthy node
+	code := status.Code(err)
+	failed := code == codes.Unavailable || code == codes.DeadlineExceeded || code == codes.ResourceExhausted
+
+	if b.state == breakerHalfOpen {
+		b.probing = false
+		if failed {
+			b.open()
+		} else {
+			b.failures = 0
+			b.setState(breakerClosed)
+		}
+		return
+	}
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == breakerClosed && b.failures >= b.cfg.FailureThreshold {
+		b.open()
+	}
+}
+
+func (b *circuitBreaker) open() {
+	b.openedAt = time.Now()
+	b.setState(breakerOpen)
+}
+
+func (b *circuitBreaker) setState(state int) {
+	if state != b.state && state == breakerOpen {
+		CircuitBreakerTrips.WithLabelValues(b.endpoint).Inc()
+	}
+	b.state = state
+	CircuitBreakerStateGauge.WithLabelValues(b.endpoint).Set(float64(state))
+}
@@ -0,0 +1,200 @@
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	types "github.com/forbole/cosmos-exporter/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    codes.Code
+		wantErr bool
+	}{
+		{"Unavailable", codes.Unavailable, false},
+		{"unavailable", codes.Unavailable, false},
+		{"RESOURCE_EXHAUSTED", codes.ResourceExhausted, false},
+		{"ResourceExhausted", codes.ResourceExhausted, false},
+		{"DeadlineExceeded", codes.DeadlineExceeded, false},
+		{"OK", codes.OK, false},
+		{"Unauthenticated", codes.Unauthenticated, false},
+		{"Timeout", codes.Unknown, true},
+		{"", codes.Unknown, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCode(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCode(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseCode(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	cfg := types.NewRetry(5, 100*time.Millisecond, time.Second, 2, nil)
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{10, time.Second},
+	}
+	for _, tt := range tests {
+		if got := backoff(cfg, tt.attempt); got != tt.want {
+			t.Errorf("backoff(attempt %d) = %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	cfg := types.NewRetry(3, time.Millisecond, time.Millisecond, 2, []string{"Unavailable"})
+	tests := []struct {
+		name         string
+		errs         []error
+		wantAttempts int
+		wantCode     codes.Code
+	}{
+		{"success", []error{nil}, 1, codes.OK},
+		{"retried then success", []error{status.Error(codes.Unavailable, ""), nil}, 2, codes.OK},
+		{"attempts exhausted", []error{status.Error(codes.Unavailable, ""), status.Error(codes.Unavailable, ""), status.Error(codes.Unavailable, "")}, 3, codes.Unavailable},
+		{"not retryable", []error{status.Error(codes.NotFound, "")}, 1, codes.NotFound},
+		{"circuit open", []error{errCircuitOpen}, 1, codes.Unavailable},
+	}
+
+	interceptor, err := Retry(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				err := tt.errs[attempts]
+				attempts++
+				return err
+			}
+
+			err := interceptor(context.Background(), "/test.Query/Method", nil, nil, nil, invoker)
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("code = %v, want %v", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestRetryInvalidCode(t *testing.T) {
+	if _, err := Retry(types.NewRetry(3, 0, 0, 0, []string{"Timeout"})); err == nil {
+		t.Error("Retry accepted an unknown status code")
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "")
+	notFound := status.Error(codes.NotFound, "")
+
+	// step is a query: elapsed is the time spent in the open state before it
+	type step struct {
+		elapsed   bool
+		err       error
+		wantAllow bool
+		wantState int
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"opens after the threshold", []step{
+			{false, unavailable, true, breakerClosed},
+			{false, unavailable, true, breakerClosed},
+			{false, unavailable, true, breakerOpen},
+			{false, nil, false, breakerOpen},
+		}},
+		{"success resets the failures", []step{
+			{false, unavailable, true, breakerClosed},
+			{false, unavailable, true, breakerClosed},
+			{false, nil, true, breakerClosed},
+			{false, unavailable, true, breakerClosed},
+			{false, unavailable, true, breakerClosed},
+		}},
+		{"healthy errors are not failures", []step{
+			{false, notFound, true, breakerClosed},
+			{false, notFound, true, breakerClosed},
+			{false, notFound, true, breakerClosed},
+		}},
+		{"half open probe succeeds", []step{
+			{false, unavailable, true, breakerClosed},
+			{false, unavailable, true, breakerClosed},
+			{false, unavailable, true, breakerOpen},
+			{true, nil, true, breakerClosed},
+			{false, unavailable, true, breakerClosed},
+		}},
+		{"half open probe fails", []step{
+			{false, unavailable, true, breakerClosed},
+			{false, unavailable, true, breakerClosed},
+			{false, unavailable, true, breakerOpen},
+			{true, unavailable, true, breakerOpen},
+			{false, nil, false, breakerOpen},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker := &circuitBreaker{cfg: types.NewCircuitBreaker(3, time.Minute), endpoint: "test:" + tt.name}
+			defer CircuitBreakerStateGauge.DeleteLabelValues(breaker.endpoint)
+			defer CircuitBreakerTrips.DeleteLabelValues(breaker.endpoint)
+
+			for i, s := range tt.steps {
+				if s.elapsed {
+					breaker.openedAt = breaker.openedAt.Add(-time.Minute)
+				}
+				allowed := breaker.allow()
+				if allowed != s.wantAllow {
+					t.Fatalf("step %d: allow() = %v, want %v", i, allowed, s.wantAllow)
+				}
+				if allowed {
+					breaker.record(s.err)
+				}
+				if breaker.state != s.wantState {
+					t.Fatalf("step %d: state = %d, want %d", i, breaker.state, s.wantState)
+				}
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	breaker := &circuitBreaker{cfg: types.NewCircuitBreaker(1, time.Minute), endpoint: "test:single probe"}
+	defer CircuitBreakerStateGauge.DeleteLabelValues(breaker.endpoint)
+	defer CircuitBreakerTrips.DeleteLabelValues(breaker.endpoint)
+
+	breaker.allow()
+	breaker.record(status.Error(codes.Unavailable, ""))
+	breaker.openedAt = breaker.openedAt.Add(-time.Minute)
+
+	if !breaker.allow() {
+		t.Fatal("the probe was not let through once the open timeout elapsed")
+	}
+	if breaker.allow() {
+		t.Error("a second query was let through while half open")
+	}
+	breaker.record(nil)
+	if !breaker.allow() {
+		t.Error("a query was refused once the probe succeeded")
+	}
+}
@@ -0,0 +1,68 @@
+package collector
+
+import (
+	"context"
+	"sync"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+type roundKey struct{}
+
+// round caches the staking queries shared by several collectors, so they are
+// sent once per collection
+type round struct {
+	validatorsOnce sync.Once
+	validators     []stakingtypes.Validator
+	validatorsErr  error
+
+	paramsOnce sync.Once
+	params     stakingtypes.Params
+	paramsErr  error
+}
+
+func withRound(ctx context.Context) context.Context {
+	return context.WithValue(ctx, roundKey{}, &round{})
+}
+
+// allValidators returns every validator, queried once per collection. The
+// slice is a copy the caller may sort.
+func (collector *CosmosSDKCollector) allValidators(ctx context.Context) ([]stakingtypes.Validator, error) {
+	r, ok := ctx.Value(roundKey{}).(*round)
+	if !ok {
+		return collector.queryValidators(ctx)
+	}
+
+	r.validatorsOnce.Do(func() {
+		r.validators, r.validatorsErr = collector.queryValidators(ctx)
+	})
+	if r.validatorsErr != nil {
+		return nil, r.validatorsErr
+	}
+	return append([]stakingtypes.Validator(nil), r.validators...), nil
+}
+
+// stakingParams returns the staking params, queried once per collection
+func (collector *CosmosSDKCollector) stakingParams(ctx context.Context) (stakingtypes.Params, error) {
+	query := func() (stakingtypes.Params, error) {
+		ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+
+		stakingClient := stakingtypes.NewQueryClient(collector.grpcConn)
+		res, err := stakingClient.Params(ctx, &stakingtypes.QueryParamsRequest{})
+		if err != nil {
+			return stakingtypes.Params{}, err
+		}
+		return res.Params, nil
+	}
+
+	r, ok := ctx.Value(roundKey{}).(*round)
+	if !ok {
+		return query()
+	}
+
+	r.paramsOnce.Do(func() {
+		r.params, r.paramsErr = query()
+	})
+	return r.params, r.paramsErr
+}
@@ -0,0 +1,64 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// cachedRound returns a context whose round already holds the results of
+// the staking queries, so no query is sent
+func cachedRound(validators []stakingtypes.Validator, params stakingtypes.Params, err error) context.Context {
+	ctx := withRound(context.Background())
+	r := ctx.Value(roundKey{}).(*round)
+	r.validatorsOnce.Do(func() { r.validators, r.validatorsErr = validators, err })
+	r.paramsOnce.Do(func() { r.params, r.paramsErr = params, err })
+	return ctx
+}
+
+func TestRoundAllValidators(t *testing.T) {
+	collector := &CosmosSDKCollector{}
+	ctx := cachedRound([]stakingtypes.Validator{{OperatorAddress: "val1"}, {OperatorAddress: "val2"}}, stakingtypes.Params{}, nil)
+
+	first, err := collector.allValidators(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first[0], first[1] = first[1], first[0]
+
+	second, err := collector.allValidators(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second[0].OperatorAddress != "val1" || second[1].OperatorAddress != "val2" {
+		t.Errorf("sorting the validators of one collector reordered the cached validators: %s, %s", second[0].OperatorAddress, second[1].OperatorAddress)
+	}
+}
+
+func TestRoundErrors(t *testing.T) {
+	collector := &CosmosSDKCollector{}
+	queryErr := errors.New("unavailable")
+	ctx := cachedRound(nil, stakingtypes.Params{}, queryErr)
+
+	if _, err := collector.allValidators(ctx); !errors.Is(err, queryErr) {
+		t.Errorf("allValidators() error = %v, want %v", err, queryErr)
+	}
+	if _, err := collector.stakingParams(ctx); !errors.Is(err, queryErr) {
+		t.Errorf("stakingParams() error = %v, want %v", err, queryErr)
+	}
+}
+
+func TestRoundStakingParams(t *testing.T) {
+	collector := &CosmosSDKCollector{}
+	ctx := cachedRound(nil, stakingtypes.Params{MaxValidators: 100, BondDenom: "uatom"}, nil)
+
+	params, err := collector.stakingParams(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.MaxValidators != 100 || params.BondDenom != "uatom" {
+		t.Errorf("stakingParams() = %+v, want the cached params", params)
+	}
+}
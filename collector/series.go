@@ -0,0 +1,266 @@
+package collector
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// chainGauges are all the metrics labeled with chain_id
+var chainGauges = []*prometheus.GaugeVec{
+	ActiveProposalGauge,
+	VotedActiveProposalGauge,
+	AvailableBalanceGauge,
+	DelegatorRewardGauge,
+	DelegatorStakeGauge,
+	ValidatorCommissionGauge,
+	ValidatorDelegationGauge,
+	ValidatorJailStatusGauge,
+	ValidatorCommissionRateGauge,
+	ValidatorVotingPowerGauge,
+	VotingPowerGauge,
+	ValidatorVotingPowerRanking,
+	BondedTokenGauge,
+	NotBondedTokenGauge,
+	CirculatingSupply,
+	InflationRate,
+	AnnualProvisionsGauge,
+	CommunityTax,
+	UnbondingTime,
+	MaxValidatorsGauge,
+	ActiveSetCutoffTokensGauge,
+	ValidatorBondedRankGauge,
+	ValidatorCutoffGapGauge,
+	ValidatorVotingPowerShareGauge,
+	ValidatorsCountGauge,
+	JailedValidatorsCountGauge,
+	NakamotoCoefficientGauge,
+	VotingPowerGiniGauge,
+	TopValidatorsShareGauge,
+	SetValidatorJailedGauge,
+	SetValidatorStatusGauge,
+	SetValidatorTokensGauge,
+	SetValidatorCommissionRateGauge,
+	ValidatorSetCappedGauge,
+	ValidatorConsensusPowerGauge,
+	ValidatorCometBFTPowerGauge,
+	ValidatorPowerMismatchGauge,
+	ValidatorStatusGauge,
+	ValidatorMinSelfDelegationGauge,
+	ValidatorSelfDelegationGauge,
+	ValidatorSelfDelegationNearMinimumGauge,
+	ValidatorCommissionMaxRateGauge,
+	ValidatorCommissionMaxChangeRateGauge,
+	ValidatorCommissionUpdateTimeGauge,
+	ValidatorUnbondingHeightGauge,
+	ValidatorUnbondingTimeGauge,
+	ValidatorOutstandingRewardsGauge,
+	ValidatorSlashesGauge,
+	ValidatorSlashedRatioGauge,
+	ValidatorLastSlashRatioGauge,
+	CommunityPoolGauge,
+	ValidatorTopDelegatorTokensGauge,
+	ValidatorTopDelegatorRankGauge,
+	ValidatorTopDelegatorsShareGauge,
+	ValidatorDelegationInflowGauge,
+	ValidatorDelegationOutflowGauge,
+	ValidatorNewDelegatorsGauge,
+	ValidatorLostDelegatorsGauge,
+	VestingOriginalGauge,
+	VestingVestedGauge,
+	VestingLockedGauge,
+	VestingDelegatedGauge,
+	VestingNextUnlockGauge,
+	SpendableBalanceGauge,
+	AccountNumberGauge,
+	AccountSequenceGauge,
+	AccountSequenceRateGauge,
+	AccountSequenceUnchangedGauge,
+	BalanceThresholdGauge,
+	BalanceBelowThresholdGauge,
+	BalanceSpendRateGauge,
+	BalanceDepletionGauge,
+}
+
+// addressGauges maps the metrics labeled with a delegator address to the name of that label
+var addressGauges = map[*prometheus.GaugeVec]string{
+	VotedActiveProposalGauge:      "voter_address",
+	AvailableBalanceGauge:         "address",
+	DelegatorRewardGauge:          "delegator_address",
+	DelegatorStakeGauge:           "delegator_address",
+	VestingOriginalGauge:          "address",
+	VestingVestedGauge:            "address",
+	VestingLockedGauge:            "address",
+	VestingDelegatedGauge:         "address",
+	VestingNextUnlockGauge:        "address",
+	SpendableBalanceGauge:         "address",
+	AccountNumberGauge:            "address",
+	AccountSequenceGauge:          "address",
+	AccountSequenceRateGauge:      "address",
+	AccountSequenceUnchangedGauge: "address",
+	BalanceThresholdGauge:         "address",
+	BalanceBelowThresholdGauge:    "address",
+	BalanceSpendRateGauge:         "address",
+	BalanceDepletionGauge:         "address",
+}
+
+// validatorGauges are the metrics labeled with the monitored validator address
+var validatorGauges = []*prometheus.GaugeVec{
+	ValidatorCommissionGauge,
+	ValidatorDelegationGauge,
+	ValidatorJailStatusGauge,
+	ValidatorCommissionRateGauge,
+	ValidatorVotingPowerGauge,
+	ValidatorVotingPowerRanking,
+	ValidatorBondedRankGauge,
+	ValidatorCutoffGapGauge,
+	ValidatorVotingPowerShareGauge,
+	ValidatorConsensusPowerGauge,
+	ValidatorCometBFTPowerGauge,
+	ValidatorPowerMismatchGauge,
+	ValidatorStatusGauge,
+	ValidatorMinSelfDelegationGauge,
+	ValidatorSelfDelegationGauge,
+	ValidatorSelfDelegationNearMinimumGauge,
+	ValidatorCommissionMaxRateGauge,
+	ValidatorCommissionMaxChangeRateGauge,
+	ValidatorCommissionUpdateTimeGauge,
+	ValidatorUnbondingHeightGauge,
+	ValidatorUnbondingTimeGauge,
+	ValidatorOutstandingRewardsGauge,
+	ValidatorSlashesGauge,
+	ValidatorSlashedRatioGauge,
+	ValidatorLastSlashRatioGauge,
+	ValidatorTopDelegatorTokensGauge,
+	ValidatorTopDelegatorRankGauge,
+	ValidatorTopDelegatorsShareGauge,
+	ValidatorDelegationInflowGauge,
+	ValidatorDelegationOutflowGauge,
+	ValidatorNewDelegatorsGauge,
+	ValidatorLostDelegatorsGauge,
+}
+
+// DeleteChainSeries removes every series exported for the given chain
+func DeleteChainSeries(chainID string) {
+	for _, gauge := range chainGauges {
+		gauge.DeletePartialMatch(prometheus.Labels{"chain_id": chainID})
+	}
+}
+
+// DeleteAddressSeries removes every series exported for a delegator address
+// that is no longer monitored
+func DeleteAddressSeries(chainID string, address string) {
+	for gauge, label := range addressGauges {
+		gauge.DeletePartialMatch(prometheus.Labels{"chain_id": chainID, label: address})
+	}
+}
+
+// DeleteValidatorSeries removes every series exported for a validator
+// that is no longer monitored
+func DeleteValidatorSeries(chainID string, valAddress string) {
+	for _, gauge := range validatorGauges {
+		gauge.DeletePartialMatch(prometheus.Labels{"chain_id": chainID, "validator_address": valAddress})
+	}
+}
+
+type seriesTrackerKey struct{}
+
+// seriesTracker records the series set by a collector run, so the series of
+// its gauges which were not refreshed can be deleted after the run
+type seriesTracker struct {
+	mu   sync.Mutex
+	seen map[*prometheus.GaugeVec]map[string][]string
+}
+
+func newSeriesTracker() *seriesTracker {
+	return &seriesTracker{seen: make(map[*prometheus.GaugeVec]map[string][]string)}
+}
+
+// exportedSeries are the label values of the series of each swept gauge set
+// by the previous runs of its collector
+var exportedSeries = struct {
+	mu     sync.Mutex
+	series map[*prometheus.GaugeVec]map[string][]string
+}{series: make(map[*prometheus.GaugeVec]map[string][]string)}
+
+// withLabelValues returns the series of gauge with the given label values,
+// recording it in the tracker of ctx if any
+func withLabelValues(ctx context.Context, gauge *prometheus.GaugeVec, lvs ...string) prometheus.Gauge {
+	series := gauge.WithLabelValues(lvs...)
+	if tracker, ok := ctx.Value(seriesTrackerKey{}).(*seriesTracker); ok {
+		tracker.add(gauge, lvs)
+	}
+	return series
+}
+
+func (t *seriesTracker) add(gauge *prometheus.GaugeVec, lvs []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.seen[gauge] == nil {
+		t.seen[gauge] = make(map[string][]string)
+	}
+	t.seen[gauge][seriesKey(lvs)] = append([]string(nil), lvs...)
+}
+
+// sweep deletes the series of gauges set by a previous run which were not
+// set by this one, except the series labeled with one of the failed
+// addresses whose values could not be refreshed
+func (t *seriesTracker) sweep(gauges []*prometheus.GaugeVec, failed []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	exportedSeries.mu.Lock()
+	defer exportedSeries.mu.Unlock()
+
+	for _, gauge := range gauges {
+		exported := make(map[string][]string, len(t.seen[gauge]))
+		for key, lvs := range exportedSeries.series[gauge] {
+			if _, seen := t.seen[gauge][key]; seen {
+				continue
+			}
+			if hasAnyValue(lvs, failed) {
+				exported[key] = lvs
+				continue
+			}
+			gauge.DeleteLabelValues(lvs...)
+		}
+		for key, lvs := range t.seen[gauge] {
+			exported[key] = lvs
+		}
+		exportedSeries.series[gauge] = exported
+	}
+}
+
+// keep records the series set by a failed run without deleting any series
+func (t *seriesTracker) keep(gauges []*prometheus.GaugeVec) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	exportedSeries.mu.Lock()
+	defer exportedSeries.mu.Unlock()
+
+	for _, gauge := range gauges {
+		if exportedSeries.series[gauge] == nil {
+			exportedSeries.series[gauge] = make(map[string][]string)
+		}
+		for key, lvs := range t.seen[gauge] {
+			exportedSeries.series[gauge][key] = lvs
+		}
+	}
+}
+
+func hasAnyValue(lvs []string, values []string) bool {
+	for _, lv := range lvs {
+		for _, value := range values {
+			if lv == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// seriesKey identifies a series of a gauge by its label values
+func seriesKey(lvs []string) string {
+	return strings.Join(lvs, "\x00")
+}
@@ -0,0 +1,129 @@
+package collector
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// exportedLabelValues returns the label values of every series of gauge,
+// ordered by label name
+func exportedLabelValues(t *testing.T, gauge *prometheus.GaugeVec) []string {
+	t.Helper()
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		gauge.Collect(ch)
+		close(ch)
+	}()
+
+	series := []string{}
+	for metric := range ch {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			t.Fatal(err)
+		}
+		lvs := []string{}
+		for _, label := range m.Label {
+			lvs = append(lvs, label.GetValue())
+		}
+		series = append(series, seriesKey(lvs))
+	}
+	sort.Strings(series)
+	return series
+}
+
+func TestSeriesTracker(t *testing.T) {
+	// run is a collector run setting the series of set, swept unless it
+	// failed without attributing the failure to addresses
+	type run struct {
+		set    [][]string
+		failed []string
+		keep   bool
+	}
+	tests := []struct {
+		name string
+		runs []run
+		want [][]string
+	}{
+		{
+			name: "series not set again are deleted",
+			runs: []run{
+				{set: [][]string{{"addr1", "chain"}, {"addr2", "chain"}}},
+				{set: [][]string{{"addr1", "chain"}}},
+			},
+			want: [][]string{{"addr1", "chain"}},
+		},
+		{
+			name: "series of failed addresses are kept",
+			runs: []run{
+				{set: [][]string{{"addr1", "chain"}, {"addr2", "chain"}}},
+				{set: [][]string{{"addr1", "chain"}}, failed: []string{"addr2"}},
+			},
+			want: [][]string{{"addr1", "chain"}, {"addr2", "chain"}},
+		},
+		{
+			name: "series kept for a failed address are deleted once it is refreshed without them",
+			runs: []run{
+				{set: [][]string{{"addr1", "chain"}, {"addr2", "chain"}}},
+				{set: [][]string{{"addr1", "chain"}}, failed: []string{"addr2"}},
+				{set: [][]string{{"addr1", "chain"}}},
+			},
+			want: [][]string{{"addr1", "chain"}},
+		},
+		{
+			name: "an unattributed failure keeps every series",
+			runs: []run{
+				{set: [][]string{{"addr1", "chain"}, {"addr2", "chain"}}},
+				{set: [][]string{{"addr3", "chain"}}, keep: true},
+			},
+			want: [][]string{{"addr1", "chain"}, {"addr2", "chain"}, {"addr3", "chain"}},
+		},
+		{
+			name: "series set during an unattributed failure are swept by the next run",
+			runs: []run{
+				{set: [][]string{{"addr1", "chain"}}},
+				{set: [][]string{{"addr2", "chain"}}, keep: true},
+				{set: [][]string{{"addr1", "chain"}}},
+			},
+			want: [][]string{{"addr1", "chain"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_series", Help: "test"}, []string{"address", "chain_id"})
+			gauges := []*prometheus.GaugeVec{gauge}
+			defer func() {
+				exportedSeries.mu.Lock()
+				delete(exportedSeries.series, gauge)
+				exportedSeries.mu.Unlock()
+			}()
+
+			for _, r := range tt.runs {
+				tracker := newSeriesTracker()
+				ctx := context.WithValue(context.Background(), seriesTrackerKey{}, tracker)
+				for _, lvs := range r.set {
+					withLabelValues(ctx, gauge, lvs...).Set(1)
+				}
+				if r.keep {
+					tracker.keep(gauges)
+				} else {
+					tracker.sweep(gauges, r.failed)
+				}
+			}
+
+			want := []string{}
+			for _, lvs := range tt.want {
+				want = append(want, seriesKey(lvs))
+			}
+			sort.Strings(want)
+			if got := exportedLabelValues(t, gauge); !reflect.DeepEqual(got, want) {
+				t.Errorf("series = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -3,21 +3,16 @@ package collector
 import (
 	"context"
 	"log"
-
-	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
-func (collector *CosmosSDKCollector) CollectUnbondingTime() {
-	stakeClient := stakingtypes.NewQueryClient(collector.grpcConn)
-	stakeRes, err := stakeClient.Params(
-		context.Background(),
-		&stakingtypes.QueryParamsRequest{},
-	)
+func (collector *CosmosSDKCollector) CollectUnbondingTime(ctx context.Context) error {
+	params, err := collector.stakingParams(ctx)
 	if err != nil {
 		ErrorGauge.WithLabelValues("tendermint_unbonding_time").Inc()
 		log.Print(err)
-		return
+		return err
 	}
 
-	UnbondingTime.WithLabelValues(collector.chainID).Set(stakeRes.Params.UnbondingTime.Seconds())
+	UnbondingTime.WithLabelValues(collector.chainID).Set(params.UnbondingTime.Seconds())
+	return nil
 }
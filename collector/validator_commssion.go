@@ -9,16 +9,20 @@ import (
 	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 )
 
-func (collector *CosmosSDKCollector) CollectValidatorCommissionGauge() {
+func (collector *CosmosSDKCollector) CollectValidatorCommissionGauge(ctx context.Context) error {
+	valAddress := collector.validatorAddress()
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
 	distributionClient := distributiontypes.NewQueryClient(collector.grpcConn)
 	distributionRes, err := distributionClient.ValidatorCommission(
-		context.Background(),
-		&distributiontypes.QueryValidatorCommissionRequest{ValidatorAddress: collector.valAddress},
+		ctx,
+		&distributiontypes.QueryValidatorCommissionRequest{ValidatorAddress: valAddress},
 	)
 	if err != nil {
 		ErrorGauge.WithLabelValues("tendermint_validator_commission_total").Inc()
 		log.Print(err)
-		return
+		return err
 	}
 
 	for _, commission := range distributionRes.Commission.Commission {
@@ -27,12 +31,14 @@ func (collector *CosmosSDKCollector) CollectValidatorCommissionGauge() {
 		} else {
 			baseDenom, found := collector.denomMetadata[commission.Denom]
 			if !found {
+				ErrorGauge.WithLabelValues("tendermint_validator_commission_total").Inc()
+				log.Printf("No denom infos for %s", commission.Denom)
 				continue
 			}
 			commissionFromBaseToDisplay := value / math.Pow10(int(baseDenom.Exponent))
 
-			ValidatorCommissionGauge.WithLabelValues(collector.valAddress, collector.chainID, baseDenom.Display).Set(commissionFromBaseToDisplay)
+			withLabelValues(ctx, ValidatorCommissionGauge, valAddress, collector.chainID, baseDenom.Display, collector.validatorMoniker()).Set(commissionFromBaseToDisplay)
 		}
 	}
-
+	return nil
 }
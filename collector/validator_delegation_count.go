@@ -11,12 +11,16 @@ import (
 
 const MaxLimit = math.MaxUint64
 
-func (collector *CosmosSDKCollector) CollectValidatorDelegationGauge() {
+func (collector *CosmosSDKCollector) CollectValidatorDelegationGauge(ctx context.Context) error {
+	valAddress := collector.validatorAddress()
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
 	stakingClient := stakingtypes.NewQueryClient(collector.grpcConn)
 	stakingRes, err := stakingClient.ValidatorDelegations(
-		context.Background(),
+		ctx,
 		&stakingtypes.QueryValidatorDelegationsRequest{
-			ValidatorAddr: collector.valAddress,
+			ValidatorAddr: valAddress,
 			Pagination: &querytypes.PageRequest{
 				CountTotal: true,
 			},
@@ -25,9 +29,10 @@ func (collector *CosmosSDKCollector) CollectValidatorDelegationGauge() {
 	if err != nil {
 		ErrorGauge.WithLabelValues("tendermint_validator_delegators_total").Inc()
 		log.Print(err)
-		return
+		return err
 	}
 
 	delegationsCount := float64(stakingRes.Pagination.Total)
-	ValidatorDelegationGauge.WithLabelValues(collector.valAddress, collector.chainID).Set(delegationsCount)
+	withLabelValues(ctx, ValidatorDelegationGauge, valAddress, collector.chainID, collector.validatorMoniker()).Set(delegationsCount)
+	return nil
 }
@@ -0,0 +1,153 @@
+package collector
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+
+	sdkmath "cosmossdk.io/math"
+	querytypes "github.com/cosmos/cosmos-sdk/types/query"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	types "github.com/forbole/cosmos-exporter/types"
+)
+
+// delegationHistory keeps the delegations to the monitored validator seen by
+// the previous collection, to compute the flows between two collections
+type delegationHistory struct {
+	mu         sync.Mutex
+	valAddress string
+	balances   map[string]sdkmath.Int
+}
+
+// CollectValidatorDelegators exports the largest delegators of the monitored
+// validator, the share of its stake they hold and the delegation flows since
+// the previous collection
+func (collector *CosmosSDKCollector) CollectValidatorDelegators(ctx context.Context) error {
+	valAddress := collector.validatorAddress()
+	if valAddress == "" {
+		return nil
+	}
+
+	baseDenom, found := collector.denomMetadata[collector.defaultBondDenom]
+	if !found {
+		ErrorGauge.WithLabelValues("cosmos_staking_validator_top_delegator_tokens").Inc()
+		log.Print("No denom infos")
+		return &types.DenomNotFound{}
+	}
+
+	balances, err := collector.queryValidatorDelegations(ctx, valAddress)
+	if err != nil {
+		ErrorGauge.WithLabelValues("cosmos_staking_validator_top_delegator_tokens").Inc()
+		log.Print(err)
+		return err
+	}
+
+	delegators := make([]string, 0, len(balances))
+	total := sdkmath.ZeroInt()
+	for delegator, balance := range balances {
+		delegators = append(delegators, delegator)
+		total = total.Add(balance)
+	}
+	sort.Slice(delegators, func(i, j int) bool {
+		if !balances[delegators[i]].Equal(balances[delegators[j]]) {
+			return balances[delegators[i]].GT(balances[delegators[j]])
+		}
+		return delegators[i] < delegators[j]
+	})
+
+	moniker := collector.validatorMoniker()
+	top := collector.delegatorsConfig().Top
+	if top > len(delegators) {
+		top = len(delegators)
+	}
+	topTotal := sdkmath.ZeroInt()
+	for i, delegator := range delegators[:top] {
+		topTotal = topTotal.Add(balances[delegator])
+		withLabelValues(ctx, ValidatorTopDelegatorTokensGauge, valAddress, collector.chainID, baseDenom.Display, moniker, delegator).Set(toDisplay(balances[delegator], baseDenom.Exponent))
+		withLabelValues(ctx, ValidatorTopDelegatorRankGauge, valAddress, collector.chainID, moniker, delegator).Set(float64(i + 1))
+	}
+	var topShare float64
+	if total.IsPositive() {
+		topShare = decToFloat(sdkmath.LegacyNewDecFromInt(topTotal).Quo(sdkmath.LegacyNewDecFromInt(total)))
+	}
+	withLabelValues(ctx, ValidatorTopDelegatorsShareGauge, valAddress, collector.chainID, moniker).Set(topShare)
+
+	collector.delegations.mu.Lock()
+	defer collector.delegations.mu.Unlock()
+
+	// The flows need a previous collection of the same validator
+	previous, previousValAddress := collector.delegations.balances, collector.delegations.valAddress
+	collector.delegations.balances, collector.delegations.valAddress = balances, valAddress
+	if previous == nil || previousValAddress != valAddress {
+		return nil
+	}
+
+	inflow, outflow, newDelegators, lostDelegators := diffDelegations(previous, balances)
+
+	withLabelValues(ctx, ValidatorDelegationInflowGauge, valAddress, collector.chainID, baseDenom.Display, moniker).Set(toDisplay(inflow, baseDenom.Exponent))
+	withLabelValues(ctx, ValidatorDelegationOutflowGauge, valAddress, collector.chainID, baseDenom.Display, moniker).Set(toDisplay(outflow, baseDenom.Exponent))
+	withLabelValues(ctx, ValidatorNewDelegatorsGauge, valAddress, collector.chainID, moniker).Set(float64(newDelegators))
+	withLabelValues(ctx, ValidatorLostDelegatorsGauge, valAddress, collector.chainID, moniker).Set(float64(lostDelegators))
+	return nil
+}
+
+// diffDelegations returns the tokens delegated and undelegated between the
+// previous and current balances by delegator, and the numbers of delegators
+// which appeared and disappeared
+func diffDelegations(previous, current map[string]sdkmath.Int) (inflow, outflow sdkmath.Int, newDelegators, lostDelegators int) {
+	inflow, outflow = sdkmath.ZeroInt(), sdkmath.ZeroInt()
+	for delegator, balance := range current {
+		before, found := previous[delegator]
+		if !found {
+			newDelegators++
+			before = sdkmath.ZeroInt()
+		}
+		if balance.GT(before) {
+			inflow = inflow.Add(balance.Sub(before))
+		} else {
+			outflow = outflow.Add(before.Sub(balance))
+		}
+	}
+	for delegator, before := range previous {
+		if _, found := current[delegator]; !found {
+			lostDelegators++
+			outflow = outflow.Add(before)
+		}
+	}
+	return inflow, outflow, newDelegators, lostDelegators
+}
+
+// queryValidatorDelegations pages through the delegations to valAddress and
+// returns their balance by delegator address
+func (collector *CosmosSDKCollector) queryValidatorDelegations(ctx context.Context, valAddress string) (map[string]sdkmath.Int, error) {
+	stakingClient := stakingtypes.NewQueryClient(collector.grpcConn)
+
+	balances := make(map[string]sdkmath.Int)
+	var nextKey []byte
+	for {
+		queryCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+		res, err := stakingClient.ValidatorDelegations(
+			queryCtx,
+			&stakingtypes.QueryValidatorDelegationsRequest{
+				ValidatorAddr: valAddress,
+				Pagination: &querytypes.PageRequest{
+					Key:   nextKey,
+					Limit: 1000,
+				},
+			},
+		)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, delegation := range res.DelegationResponses {
+			balances[delegation.Delegation.DelegatorAddress] = delegation.Balance.Amount
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return balances, nil
+		}
+		nextKey = res.Pagination.NextKey
+	}
+}
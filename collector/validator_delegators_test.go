@@ -0,0 +1,84 @@
+package collector
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+)
+
+func TestDiffDelegations(t *testing.T) {
+	tests := []struct {
+		name        string
+		previous    map[string]int64
+		current     map[string]int64
+		wantInflow  int64
+		wantOutflow int64
+		wantNew     int
+		wantLost    int
+	}{
+		{
+			name:     "unchanged",
+			previous: map[string]int64{"a": 100, "b": 50},
+			current:  map[string]int64{"a": 100, "b": 50},
+		},
+		{
+			name:       "delegated more",
+			previous:   map[string]int64{"a": 100},
+			current:    map[string]int64{"a": 150},
+			wantInflow: 50,
+		},
+		{
+			name:        "partially undelegated",
+			previous:    map[string]int64{"a": 100},
+			current:     map[string]int64{"a": 40},
+			wantOutflow: 60,
+		},
+		{
+			name:       "new delegator",
+			previous:   map[string]int64{"a": 100},
+			current:    map[string]int64{"a": 100, "b": 30},
+			wantInflow: 30,
+			wantNew:    1,
+		},
+		{
+			name:        "lost delegator",
+			previous:    map[string]int64{"a": 100, "b": 30},
+			current:     map[string]int64{"a": 100},
+			wantOutflow: 30,
+			wantLost:    1,
+		},
+		{
+			name:        "flows in both directions",
+			previous:    map[string]int64{"a": 100, "b": 30, "c": 10},
+			current:     map[string]int64{"a": 120, "b": 5, "d": 7},
+			wantInflow:  27,
+			wantOutflow: 35,
+			wantNew:     1,
+			wantLost:    1,
+		},
+	}
+	toInts := func(balances map[string]int64) map[string]sdkmath.Int {
+		ints := make(map[string]sdkmath.Int, len(balances))
+		for delegator, balance := range balances {
+			ints[delegator] = sdkmath.NewInt(balance)
+		}
+		return ints
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inflow, outflow, newDelegators, lostDelegators := diffDelegations(toInts(tt.previous), toInts(tt.current))
+			if !inflow.Equal(sdkmath.NewInt(tt.wantInflow)) {
+				t.Errorf("inflow = %s, want %d", inflow, tt.wantInflow)
+			}
+			if !outflow.Equal(sdkmath.NewInt(tt.wantOutflow)) {
+				t.Errorf("outflow = %s, want %d", outflow, tt.wantOutflow)
+			}
+			if newDelegators != tt.wantNew {
+				t.Errorf("new delegators = %d, want %d", newDelegators, tt.wantNew)
+			}
+			if lostDelegators != tt.wantLost {
+				t.Errorf("lost delegators = %d, want %d", lostDelegators, tt.wantLost)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	types "github.com/forbole/cosmos-exporter/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CollectValidatorDetails exports the bond status, self-delegation and
+// commission bounds of the monitored validator
+func (collector *CosmosSDKCollector) CollectValidatorDetails(ctx context.Context) error {
+	valAddress := collector.validatorAddress()
+	if valAddress == "" {
+		return nil
+	}
+
+	validatorCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	stakingClient := stakingtypes.NewQueryClient(collector.grpcConn)
+	validatorRes, err := stakingClient.Validator(validatorCtx, &stakingtypes.QueryValidatorRequest{ValidatorAddr: valAddress})
+	if err != nil {
+		ErrorGauge.WithLabelValues("cosmos_staking_validator_status").Inc()
+		log.Print(err)
+		return err
+	}
+	validator := validatorRes.Validator
+	moniker := collector.validatorMoniker()
+
+	withLabelValues(ctx, ValidatorStatusGauge, valAddress, collector.chainID, moniker).Set(float64(validator.Status))
+
+	rates := validator.Commission.CommissionRates
+	if maxRate, err := strconv.ParseFloat(rates.MaxRate.String(), 64); err != nil {
+		ErrorGauge.WithLabelValues("cosmos_staking_validator_commission_max_ratio").Inc()
+		log.Print(err)
+	} else {
+		withLabelValues(ctx, ValidatorCommissionMaxRateGauge, valAddress, collector.chainID, moniker).Set(maxRate)
+	}
+	if maxChangeRate, err := strconv.ParseFloat(rates.MaxChangeRate.String(), 64); err != nil {
+		ErrorGauge.WithLabelValues("cosmos_staking_validator_commission_max_change_ratio").Inc()
+		log.Print(err)
+	} else {
+		withLabelValues(ctx, ValidatorCommissionMaxChangeRateGauge, valAddress, collector.chainID, moniker).Set(maxChangeRate)
+	}
+	withLabelValues(ctx, ValidatorCommissionUpdateTimeGauge, valAddress, collector.chainID, moniker).Set(float64(validator.Commission.UpdateTime.Unix()))
+
+	// Only set while the validator is unbonding or unbonded, both are deleted
+	// when it bonds again
+	if validator.Status == stakingtypes.Bonded {
+		ValidatorUnbondingHeightGauge.DeleteLabelValues(valAddress, collector.chainID, moniker)
+		ValidatorUnbondingTimeGauge.DeleteLabelValues(valAddress, collector.chainID, moniker)
+	} else {
+		withLabelValues(ctx, ValidatorUnbondingHeightGauge, valAddress, collector.chainID, moniker).Set(float64(validator.UnbondingHeight))
+		withLabelValues(ctx, ValidatorUnbondingTimeGauge, valAddress, collector.chainID, moniker).Set(float64(validator.UnbondingTime.Unix()))
+	}
+
+	baseDenom, found := collector.denomMetadata[collector.defaultBondDenom]
+	if !found {
+		ErrorGauge.WithLabelValues("cosmos_staking_validator_self_delegation_tokens").Inc()
+		log.Print("No denom infos")
+		return &types.DenomNotFound{}
+	}
+
+	selfDelegation, err := collector.selfDelegation(ctx, stakingClient)
+	if err != nil {
+		ErrorGauge.WithLabelValues("cosmos_staking_validator_self_delegation_tokens").Inc()
+		log.Print(err)
+		return err
+	}
+
+	// Close to the minimum when less than the margin above it
+	var nearMinimum float64
+	threshold := sdkmath.LegacyNewDecFromInt(validator.MinSelfDelegation).Mul(sdkmath.LegacyOneDec().Add(collector.selfDelegationMargin()))
+	if sdkmath.LegacyNewDecFromInt(selfDelegation).LT(threshold) {
+		nearMinimum = 1
+	}
+
+	withLabelValues(ctx, ValidatorMinSelfDelegationGauge, valAddress, collector.chainID, baseDenom.Display, moniker).Set(toDisplay(validator.MinSelfDelegation, baseDenom.Exponent))
+	withLabelValues(ctx, ValidatorSelfDelegationGauge, valAddress, collector.chainID, baseDenom.Display, moniker).Set(toDisplay(selfDelegation, baseDenom.Exponent))
+	withLabelValues(ctx, ValidatorSelfDelegationNearMinimumGauge, valAddress, collector.chainID, moniker).Set(nearMinimum)
+	return nil
+}
+
+// selfDelegation returns the tokens delegated by the account of the validator operator
+func (collector *CosmosSDKCollector) selfDelegation(ctx context.Context, stakingClient stakingtypes.QueryClient) (sdkmath.Int, error) {
+	valAddress := collector.validatorAddress()
+	accAddress, err := operatorAccount(valAddress)
+	if err != nil {
+		return sdkmath.ZeroInt(), err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	delegationRes, err := stakingClient.Delegation(ctx, &stakingtypes.QueryDelegationRequest{
+		DelegatorAddr: accAddress,
+		ValidatorAddr: valAddress,
+	})
+	if status.Code(err) == codes.NotFound {
+		return sdkmath.ZeroInt(), nil
+	}
+	if err != nil {
+		return sdkmath.ZeroInt(), err
+	}
+	return delegationRes.DelegationResponse.Balance.Amount, nil
+}
+
+// operatorAccount returns the account address of a validator operator address,
+// e.g. cosmos1... for cosmosvaloper1...
+func operatorAccount(valAddress string) (string, error) {
+	hrp, bz, err := bech32.DecodeAndConvert(valAddress)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasSuffix(hrp, "valoper") {
+		return "", fmt.Errorf("%s is not a validator operator address", valAddress)
+	}
+	return bech32.ConvertAndEncode(strings.TrimSuffix(hrp, "valoper"), bz)
+}
+
+// selfDelegationMargin returns the configured self-delegation margin
+func (collector *CosmosSDKCollector) selfDelegationMargin() sdkmath.LegacyDec {
+	margin, err := sdkmath.LegacyNewDecFromStr(strconv.FormatFloat(collector.validatorConfig().SelfDelegationMargin, 'f', -1, 64))
+	if err != nil {
+		return sdkmath.LegacyNewDecWithPrec(1, 1)
+	}
+	return margin
+}
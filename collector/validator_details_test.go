@@ -0,0 +1,71 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+func TestCollectValidatorDetailsStatus(t *testing.T) {
+	for _, status := range []stakingtypes.BondStatus{stakingtypes.Unbonded, stakingtypes.Unbonding, stakingtypes.Bonded} {
+		t.Run(status.String(), func(t *testing.T) {
+			conn := newTestConn(t, func(server *grpc.Server) {
+				stakingtypes.RegisterQueryServer(server, &fakeStaking{validators: []stakingtypes.Validator{testValidator("val1", status, 100)}})
+			})
+			collector := newTestCollector(conn, "val1")
+			defer DeleteChainSeries(collector.chainID)
+
+			// The self-delegation of the test operator address cannot be
+			// queried, which happens after the status is set
+			if err := collector.CollectValidatorDetails(context.Background()); err == nil {
+				t.Fatal("CollectValidatorDetails() error = nil, want the operator address error")
+			}
+
+			// Same encoding as cosmos_staking_set_validator_status
+			labels := prometheus.Labels{"chain_id": "test-1", "validator_address": "val1"}
+			if got, _ := gaugeValue(t, ValidatorStatusGauge, labels); got != float64(status) {
+				t.Errorf("status = %v, want %v", got, float64(status))
+			}
+		})
+	}
+}
+
+func TestOperatorAccount(t *testing.T) {
+	bz := bytes.Repeat([]byte{0x01}, 20)
+	encode := func(hrp string) string {
+		address, err := bech32.ConvertAndEncode(hrp, bz)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return address
+	}
+
+	tests := []struct {
+		name       string
+		valAddress string
+		want       string
+		wantErr    bool
+	}{
+		{"cosmos operator", encode("cosmosvaloper"), encode("cosmos"), false},
+		{"chain operator", encode("osmovaloper"), encode("osmo"), false},
+		{"account address", encode("cosmos"), "", true},
+		{"consensus address", encode("cosmosvalcons"), "", true},
+		{"invalid address", "cosmosvaloper1invalid", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := operatorAccount(tt.valAddress)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("operatorAccount(%s) error = %v, wantErr %v", tt.valAddress, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("operatorAccount(%s) = %s, want %s", tt.valAddress, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package collector
+
+import (
+	"context"
+	"log"
+
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// CollectValidatorOutstandingRewards exports the rewards of the monitored
+// validator not withdrawn yet, by its delegators and as commission
+func (collector *CosmosSDKCollector) CollectValidatorOutstandingRewards(ctx context.Context) error {
+	valAddress := collector.validatorAddress()
+	if valAddress == "" {
+		return nil
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	distributionClient := distributiontypes.NewQueryClient(collector.grpcConn)
+	distributionRes, err := distributionClient.ValidatorOutstandingRewards(
+		queryCtx,
+		&distributiontypes.QueryValidatorOutstandingRewardsRequest{ValidatorAddress: valAddress},
+	)
+	if err != nil {
+		ErrorGauge.WithLabelValues("cosmos_distribution_validator_outstanding_rewards_tokens").Inc()
+		log.Print(err)
+		return err
+	}
+
+	moniker := collector.validatorMoniker()
+	for _, reward := range distributionRes.Rewards.Rewards {
+		denom, amount, found := collector.decCoinToDisplay(reward.Denom, reward.Amount)
+		if !found {
+			ErrorGauge.WithLabelValues("cosmos_distribution_validator_outstanding_rewards_tokens").Inc()
+			log.Printf("No denom infos for %s", reward.Denom)
+			continue
+		}
+		withLabelValues(ctx, ValidatorOutstandingRewardsGauge, valAddress, collector.chainID, denom, moniker).Set(amount)
+	}
+	return nil
+}
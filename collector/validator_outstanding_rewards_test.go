@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+func TestCollectValidatorOutstandingRewards(t *testing.T) {
+	tests := []struct {
+		name       string
+		valAddress string
+		rewards    sdk.DecCoins
+		want       map[string]float64
+	}{
+		{"no monitored validator", "", sdk.NewDecCoins(sdk.NewDecCoinFromDec("uatom", sdkmath.LegacyNewDec(1_500_000))), map[string]float64{}},
+		{"no rewards", "val1", nil, map[string]float64{}},
+		{
+			name:       "rewards in display denom",
+			valAddress: "val1",
+			rewards:    sdk.NewDecCoins(sdk.NewDecCoinFromDec("uatom", sdkmath.LegacyMustNewDecFromStr("1500000.5"))),
+			want:       map[string]float64{"atom": 1.5000005},
+		},
+		{
+			name:       "denom without metadata is skipped",
+			valAddress: "val1",
+			rewards:    sdk.NewDecCoins(sdk.NewDecCoinFromDec("ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", sdkmath.LegacyNewDec(10))),
+			want:       map[string]float64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := newTestConn(t, func(server *grpc.Server) {
+				distributiontypes.RegisterQueryServer(server, &fakeDistribution{rewards: tt.rewards})
+			})
+			collector := newTestCollector(conn, tt.valAddress)
+			defer DeleteChainSeries(collector.chainID)
+
+			if err := collector.CollectValidatorOutstandingRewards(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			got := make(map[string]float64)
+			for _, denom := range labelValues(t, ValidatorOutstandingRewardsGauge, "denom") {
+				got[denom], _ = gaugeValue(t, ValidatorOutstandingRewardsGauge, prometheus.Labels{"validator_address": tt.valAddress, "denom": denom})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("outstanding rewards = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
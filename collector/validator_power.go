@@ -0,0 +1,108 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"log"
+
+	sdkmath "cosmossdk.io/math"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// interfaceRegistry unpacks the consensus public keys of validators and the
+// accounts returned by the auth module
+var interfaceRegistry = func() codectypes.InterfaceRegistry {
+	registry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(registry)
+	authtypes.RegisterInterfaces(registry)
+	vestingtypes.RegisterInterfaces(registry)
+	return registry
+}()
+
+// CollectValidatorPower exports the consensus power of the monitored
+// validator computed from its tokens and the power CometBFT reports for it.
+// They differ when validator.power_reduction does not match the chain, or for a block
+// or two after a change while CometBFT applies the validator set update.
+// The mismatch is only exported when validator.power_reduction is set, as
+// the Cosmos SDK default does not hold on every chain.
+func (collector *CosmosSDKCollector) CollectValidatorPower(ctx context.Context) error {
+	valAddress := collector.validatorAddress()
+	if valAddress == "" {
+		return nil
+	}
+
+	validatorCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	stakingClient := stakingtypes.NewQueryClient(collector.grpcConn)
+	validatorRes, err := stakingClient.Validator(validatorCtx, &stakingtypes.QueryValidatorRequest{ValidatorAddr: valAddress})
+	if err != nil {
+		ErrorGauge.WithLabelValues("cosmos_staking_validator_consensus_power").Inc()
+		log.Print(err)
+		return err
+	}
+	validator := validatorRes.Validator
+
+	var pubKey cryptotypes.PubKey
+	if err := interfaceRegistry.UnpackAny(validator.ConsensusPubkey, &pubKey); err != nil {
+		ErrorGauge.WithLabelValues("cosmos_staking_validator_cometbft_voting_power").Inc()
+		log.Printf("Error unpacking the consensus public key of %s: %v", valAddress, err)
+		return err
+	}
+
+	rpcCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	cometValidators, err := nodeValidators(rpcCtx, collector.rpcClient)
+	if err != nil {
+		ErrorGauge.WithLabelValues("cosmos_staking_validator_cometbft_voting_power").Inc()
+		log.Print(err)
+		return err
+	}
+
+	// Validators out of the active set are not returned by CometBFT
+	var cometPower int64
+	for _, cometValidator := range cometValidators {
+		if bytes.Equal(cometValidator.Address, pubKey.Address()) {
+			cometPower = cometValidator.VotingPower
+			break
+		}
+	}
+
+	powerReduction, configured := collector.powerReduction()
+	consensusPower := validator.ConsensusPower(powerReduction)
+
+	moniker := collector.validatorMoniker()
+	withLabelValues(ctx, ValidatorConsensusPowerGauge, valAddress, collector.chainID, moniker).Set(float64(consensusPower))
+	withLabelValues(ctx, ValidatorCometBFTPowerGauge, valAddress, collector.chainID, moniker).Set(float64(cometPower))
+	if !configured {
+		ValidatorPowerMismatchGauge.DeleteLabelValues(valAddress, collector.chainID, moniker)
+		return nil
+	}
+
+	expectedPower := consensusPower
+	if !validator.IsBonded() {
+		expectedPower = 0
+	}
+	var mismatch float64
+	if expectedPower != cometPower {
+		mismatch = 1
+	}
+	withLabelValues(ctx, ValidatorPowerMismatchGauge, valAddress, collector.chainID, moniker).Set(mismatch)
+	return nil
+}
+
+// powerReduction returns the number of tokens per unit of consensus power,
+// the Cosmos SDK default when validator.power_reduction is unset
+func (collector *CosmosSDKCollector) powerReduction() (sdkmath.Int, bool) {
+	if reduction := collector.validatorConfig().PowerReduction; reduction > 0 {
+		return sdkmath.NewInt(reduction), true
+	}
+	return sdk.DefaultPowerReduction, false
+}
@@ -0,0 +1,86 @@
+package collector
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	types "github.com/forbole/cosmos-exporter/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+func TestCollectValidatorPower(t *testing.T) {
+	pubKey := ed25519.GenPrivKey().PubKey()
+	consensusPubkey, err := codectypes.NewAnyWithValue(pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// cometValidators returns the CometBFT validator set holding the
+	// monitored validator with power, or another validator when power is 0
+	cometValidators := func(power int64) string {
+		if power == 0 {
+			other := ed25519.GenPrivKey().PubKey()
+			return fmt.Sprintf(`{"block_height": "100", "validators": [{"address": "%X", "pub_key": {"type": "tendermint/PubKeyEd25519", "value": "%s"}, "voting_power": "5", "proposer_priority": "0"}], "count": "1", "total": "1"}`,
+				other.Address(), base64.StdEncoding.EncodeToString(other.Bytes()))
+		}
+		return fmt.Sprintf(`{"block_height": "100", "validators": [{"address": "%X", "pub_key": {"type": "tendermint/PubKeyEd25519", "value": "%s"}, "voting_power": "%d", "proposer_priority": "0"}], "count": "1", "total": "1"}`,
+			pubKey.Address(), base64.StdEncoding.EncodeToString(pubKey.Bytes()), power)
+	}
+
+	tests := []struct {
+		name           string
+		status         stakingtypes.BondStatus
+		tokens         sdkmath.Int
+		powerReduction int64
+		cometPower     int64
+		wantConsensus  float64
+		wantMismatch   float64
+		// wantChecked is false when no mismatch is exported
+		wantChecked bool
+	}{
+		{"matching power", stakingtypes.Bonded, sdkmath.NewInt(10_000_000), 1_000_000, 10, 10, 0, true},
+		{"mismatching power", stakingtypes.Bonded, sdkmath.NewInt(10_000_000), 1_000_000, 11, 10, 1, true},
+		{"configured power reduction", stakingtypes.Bonded, sdkmath.NewIntWithDecimal(10, 18), 1_000_000_000_000_000_000, 10, 10, 0, true},
+		{"unbonded validator out of the set", stakingtypes.Unbonded, sdkmath.NewInt(10_000_000), 1_000_000, 0, 0, 0, true},
+		{"unset power reduction", stakingtypes.Bonded, sdkmath.NewIntWithDecimal(10, 18), 0, 10, 10_000_000_000_000, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := testValidator("val1", tt.status, 0)
+			validator.Tokens = tt.tokens
+			validator.ConsensusPubkey = consensusPubkey
+			conn := newTestConn(t, func(server *grpc.Server) {
+				stakingtypes.RegisterQueryServer(server, &fakeStaking{validators: []stakingtypes.Validator{validator}})
+			})
+			collector := newTestCollector(conn, "val1")
+			collector.rpcClient = newTestRPC(t, map[string]string{"validators": cometValidators(tt.cometPower)})
+			collector.validator = types.Validator{PowerReduction: tt.powerReduction}.WithDefaults()
+			defer DeleteChainSeries(collector.chainID)
+
+			if err := collector.CollectValidatorPower(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			labels := prometheus.Labels{"chain_id": "test-1", "validator_address": "val1"}
+			if got, _ := gaugeValue(t, ValidatorConsensusPowerGauge, labels); got != tt.wantConsensus {
+				t.Errorf("consensus power = %v, want %v", got, tt.wantConsensus)
+			}
+			if got, _ := gaugeValue(t, ValidatorCometBFTPowerGauge, labels); got != float64(tt.cometPower) {
+				t.Errorf("CometBFT power = %v, want %v", got, tt.cometPower)
+			}
+			got, found := gaugeValue(t, ValidatorPowerMismatchGauge, labels)
+			if found != tt.wantChecked {
+				t.Fatalf("mismatch exported = %v, want %v", found, tt.wantChecked)
+			}
+			if got != tt.wantMismatch {
+				t.Errorf("mismatch = %v, want %v", got, tt.wantMismatch)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package collector
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+
+	types "github.com/forbole/cosmos-exporter/types"
+)
+
+// SetValidatorSet replaces the config of the validator set export
+func (c *CosmosSDKCollector) SetValidatorSet(validatorSet types.ValidatorSet) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.validatorSet = validatorSet.WithDefaults()
+}
+
+func (c *CosmosSDKCollector) validatorSetConfig() types.ValidatorSet {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.validatorSet
+}
+
+// CollectValidatorSet exports the jail status, commission rate, tokens and
+// status of the top validators by tokens, among the allowlist when set, up to
+// the configured cap
+func (collector *CosmosSDKCollector) CollectValidatorSet(ctx context.Context) error {
+	cfg := collector.validatorSetConfig()
+	if !cfg.Enabled {
+		return nil
+	}
+
+	validators, err := collector.allValidators(ctx)
+	if err != nil {
+		ErrorGauge.WithLabelValues("cosmos_staking_set_validator_tokens").Inc()
+		log.Print(err)
+		return err
+	}
+
+	baseDenom, found := collector.denomMetadata[collector.defaultBondDenom]
+	if !found {
+		ErrorGauge.WithLabelValues("cosmos_staking_set_validator_tokens").Inc()
+		log.Print("No denom infos")
+		return &types.DenomNotFound{}
+	}
+
+	sort.SliceStable(validators, func(i, j int) bool {
+		return validators[i].Tokens.GT(validators[j].Tokens)
+	})
+
+	selected := validators
+	if len(cfg.Allowlist) > 0 {
+		allowed := make(map[string]bool, len(cfg.Allowlist))
+		for _, address := range cfg.Allowlist {
+			allowed[address] = true
+		}
+		selected = nil
+		for _, validator := range validators {
+			if allowed[validator.OperatorAddress] {
+				selected = append(selected, validator)
+			}
+		}
+	}
+	if cfg.Top > 0 && cfg.Top < len(selected) {
+		selected = selected[:cfg.Top]
+	}
+
+	var capped int
+	if len(selected) > cfg.MaxValidators {
+		capped = len(selected) - cfg.MaxValidators
+		log.Printf("Validator set export is capped at %d validators, %d are not exported", cfg.MaxValidators, capped)
+		selected = selected[:cfg.MaxValidators]
+	}
+	ValidatorSetCappedGauge.WithLabelValues(collector.chainID).Set(float64(capped))
+
+	for _, validator := range selected {
+		address := validator.OperatorAddress
+		moniker := collector.monikerOf(validator)
+
+		var jailed float64
+		if validator.Jailed {
+			jailed = 1
+		}
+		withLabelValues(ctx, SetValidatorJailedGauge, address, collector.chainID, moniker).Set(jailed)
+		withLabelValues(ctx, SetValidatorStatusGauge, address, collector.chainID, moniker).Set(float64(validator.Status))
+		withLabelValues(ctx, SetValidatorTokensGauge, address, collector.chainID, baseDenom.Display, moniker).Set(toDisplay(validator.Tokens, baseDenom.Exponent))
+
+		if rate, err := strconv.ParseFloat(validator.Commission.CommissionRates.Rate.String(), 64); err != nil {
+			ErrorGauge.WithLabelValues("cosmos_staking_set_validator_commission_ratio").Inc()
+			log.Print(err)
+		} else {
+			withLabelValues(ctx, SetValidatorCommissionRateGauge, address, collector.chainID, moniker).Set(rate)
+		}
+	}
+	return nil
+}
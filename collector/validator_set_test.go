@@ -0,0 +1,75 @@
+package collector
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	types "github.com/forbole/cosmos-exporter/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+func TestCollectValidatorSet(t *testing.T) {
+	validators := []stakingtypes.Validator{
+		testValidator("val3", stakingtypes.Unbonded, 300_000_000),
+		testValidator("val1", stakingtypes.Bonded, 500_000_000),
+		testValidator("val4", stakingtypes.Unbonding, 100_000_000),
+		testValidator("val2", stakingtypes.Bonded, 400_000_000),
+	}
+
+	tests := []struct {
+		name       string
+		cfg        types.ValidatorSet
+		want       []string
+		wantCapped float64
+	}{
+		{"every validator", types.NewValidatorSet(true, 0, nil, 0), []string{"val1", "val2", "val3", "val4"}, 0},
+		{"top validators by tokens", types.NewValidatorSet(true, 2, nil, 0), []string{"val1", "val2"}, 0},
+		{"allowlist", types.NewValidatorSet(true, 0, []string{"val4", "val2", "val5"}, 0), []string{"val2", "val4"}, 0},
+		{"top validators within the allowlist", types.NewValidatorSet(true, 1, []string{"val4", "val2", "val5"}, 0), []string{"val2"}, 0},
+		{"capped", types.NewValidatorSet(true, 0, nil, 3), []string{"val1", "val2", "val3"}, 1},
+		{"top above the cap", types.NewValidatorSet(true, 3, nil, 1), []string{"val1"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := newTestConn(t, func(server *grpc.Server) {
+				stakingtypes.RegisterQueryServer(server, &fakeStaking{validators: validators})
+			})
+			collector := newTestCollector(conn, "")
+			collector.SetValidatorSet(tt.cfg)
+			defer DeleteChainSeries(collector.chainID)
+
+			if err := collector.CollectValidatorSet(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := labelValues(t, SetValidatorTokensGauge, "validator_address"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("exported validators = %v, want %v", got, tt.want)
+			}
+			if got, _ := gaugeValue(t, ValidatorSetCappedGauge, prometheus.Labels{"chain_id": "test-1"}); got != tt.wantCapped {
+				t.Errorf("capped = %v, want %v", got, tt.wantCapped)
+			}
+			if got, _ := gaugeValue(t, SetValidatorTokensGauge, prometheus.Labels{"validator_address": tt.want[0], "denom": "atom"}); got == 0 {
+				t.Errorf("tokens of %s not exported in atom", tt.want[0])
+			}
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		conn := newTestConn(t, func(server *grpc.Server) {
+			stakingtypes.RegisterQueryServer(server, &fakeStaking{validators: validators})
+		})
+		collector := newTestCollector(conn, "")
+		collector.SetValidatorSet(types.NewValidatorSet(false, 0, nil, 0))
+		defer DeleteChainSeries(collector.chainID)
+
+		if err := collector.CollectValidatorSet(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if got := labelValues(t, SetValidatorTokensGauge, "validator_address"); len(got) != 0 {
+			t.Errorf("exported validators = %v, want none", got)
+		}
+	})
+}
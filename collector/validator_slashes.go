@@ -0,0 +1,81 @@
+package collector
+
+import (
+	"context"
+	"log"
+
+	sdkmath "cosmossdk.io/math"
+	querytypes "github.com/cosmos/cosmos-sdk/types/query"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// CollectValidatorSlashes exports the slashes of the monitored validator
+// over the last validator.slash_window blocks, or its whole history when unset
+func (collector *CosmosSDKCollector) CollectValidatorSlashes(ctx context.Context) error {
+	valAddress := collector.validatorAddress()
+	if valAddress == "" {
+		return nil
+	}
+
+	statusCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	// The query requires an ending height
+	status, err := nodeStatus(statusCtx, collector.rpcClient)
+	if err != nil {
+		ErrorGauge.WithLabelValues("cosmos_distribution_validator_slashes").Inc()
+		log.Print(err)
+		return err
+	}
+	endingHeight := uint64(status.SyncInfo.LatestBlockHeight)
+	var startingHeight uint64
+	if window := uint64(collector.validatorConfig().SlashWindow); window > 0 && window < endingHeight {
+		startingHeight = endingHeight - window
+	}
+
+	distributionClient := distributiontypes.NewQueryClient(collector.grpcConn)
+
+	var slashes []distributiontypes.ValidatorSlashEvent
+	var nextKey []byte
+	for {
+		queryCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+		res, err := distributionClient.ValidatorSlashes(
+			queryCtx,
+			&distributiontypes.QueryValidatorSlashesRequest{
+				ValidatorAddress: valAddress,
+				StartingHeight:   startingHeight,
+				EndingHeight:     endingHeight,
+				Pagination: &querytypes.PageRequest{
+					Key:   nextKey,
+					Limit: 1000,
+				},
+			},
+		)
+		cancel()
+		if err != nil {
+			ErrorGauge.WithLabelValues("cosmos_distribution_validator_slashes").Inc()
+			log.Print(err)
+			return err
+		}
+
+		slashes = append(slashes, res.Slashes...)
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			break
+		}
+		nextKey = res.Pagination.NextKey
+	}
+
+	// Slashes compound, each one takes its fraction of what was left
+	remaining := sdkmath.LegacyOneDec()
+	lastFraction := sdkmath.LegacyZeroDec()
+	for _, slash := range slashes {
+		remaining = remaining.Mul(sdkmath.LegacyOneDec().Sub(slash.Fraction))
+		lastFraction = slash.Fraction
+	}
+
+	moniker := collector.validatorMoniker()
+	withLabelValues(ctx, ValidatorSlashesGauge, valAddress, collector.chainID, moniker).Set(float64(len(slashes)))
+	withLabelValues(ctx, ValidatorSlashedRatioGauge, valAddress, collector.chainID, moniker).Set(decToFloat(sdkmath.LegacyOneDec().Sub(remaining)))
+	withLabelValues(ctx, ValidatorLastSlashRatioGauge, valAddress, collector.chainID, moniker).Set(decToFloat(lastFraction))
+	return nil
+}
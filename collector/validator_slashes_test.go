@@ -0,0 +1,64 @@
+package collector
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+func TestCollectValidatorSlashes(t *testing.T) {
+	slash := func(height uint64, fraction string) distributiontypes.ValidatorSlashEvent {
+		return distributiontypes.ValidatorSlashEvent{ValidatorPeriod: height, Fraction: sdkmath.LegacyMustNewDecFromStr(fraction)}
+	}
+
+	tests := []struct {
+		name             string
+		slashWindow      int64
+		slashes          []distributiontypes.ValidatorSlashEvent
+		wantStarting     uint64
+		wantSlashes      float64
+		wantSlashedRatio float64
+		wantLastRatio    float64
+	}{
+		{"never slashed", 0, nil, 0, 0, 0, 0},
+		{"single slash", 0, []distributiontypes.ValidatorSlashEvent{slash(10, "0.05")}, 0, 1, 0.05, 0.05},
+		{"slashes compound", 0, []distributiontypes.ValidatorSlashEvent{slash(10, "0.1"), slash(20, "0.5")}, 0, 2, 0.55, 0.5},
+		{"slash window", 30, []distributiontypes.ValidatorSlashEvent{slash(80, "0.01")}, 70, 1, 0.01, 0.01},
+		{"slash window longer than the chain", 1000, nil, 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distribution := &fakeDistribution{slashes: tt.slashes}
+			conn := newTestConn(t, func(server *grpc.Server) {
+				distributiontypes.RegisterQueryServer(server, distribution)
+			})
+			collector := newTestCollector(conn, "val1")
+			collector.rpcClient = newTestRPC(t, map[string]string{"status": testStatus})
+			collector.validator.SlashWindow = tt.slashWindow
+			defer DeleteChainSeries(collector.chainID)
+
+			if err := collector.CollectValidatorSlashes(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			if req := distribution.slashesRequest; req.StartingHeight != tt.wantStarting || req.EndingHeight != 100 {
+				t.Errorf("queried heights %d to %d, want %d to 100", req.StartingHeight, req.EndingHeight, tt.wantStarting)
+			}
+			labels := prometheus.Labels{"chain_id": "test-1", "validator_address": "val1"}
+			if got, _ := gaugeValue(t, ValidatorSlashesGauge, labels); got != tt.wantSlashes {
+				t.Errorf("slashes = %v, want %v", got, tt.wantSlashes)
+			}
+			if got, _ := gaugeValue(t, ValidatorSlashedRatioGauge, labels); math.Abs(got-tt.wantSlashedRatio) > 1e-9 {
+				t.Errorf("slashed ratio = %v, want %v", got, tt.wantSlashedRatio)
+			}
+			if got, _ := gaugeValue(t, ValidatorLastSlashRatioGauge, labels); math.Abs(got-tt.wantLastRatio) > 1e-9 {
+				t.Errorf("last slash ratio = %v, want %v", got, tt.wantLastRatio)
+			}
+		})
+	}
+}
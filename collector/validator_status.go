@@ -3,23 +3,47 @@ package collector
 import (
 	"context"
 	"log"
-	"math"
 	"strconv"
 
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	types "github.com/forbole/cosmos-exporter/types"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-func (collector *CosmosSDKCollector) CollectValidatorStat() {
+// validatorStatusGauges are the gauges set from the Validator query
+type validatorStatusGauges struct {
+	jailed         *prometheus.GaugeVec
+	commissionRate *prometheus.GaugeVec
+	votingPower    *prometheus.GaugeVec
+}
+
+func (collector *CosmosSDKCollector) CollectValidatorStat(ctx context.Context) error {
+	valAddress := collector.validatorAddress()
+	return collector.collectValidatorStatus(ctx, valAddress, validatorStatusGauges{
+		jailed:         ValidatorJailStatusGauge,
+		commissionRate: ValidatorCommissionRateGauge,
+		votingPower:    ValidatorVotingPowerGauge,
+	})
+}
+
+// collectValidatorStatus sets the jail status, commission rate and voting power of address on gauges
+func (collector *CosmosSDKCollector) collectValidatorStatus(ctx context.Context, address string, gauges validatorStatusGauges) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
 	stakingClient := stakingtypes.NewQueryClient(collector.grpcConn)
 	validator, err := stakingClient.Validator(
-		context.Background(),
-		&stakingtypes.QueryValidatorRequest{ValidatorAddr: collector.valAddress},
+		ctx,
+		&stakingtypes.QueryValidatorRequest{ValidatorAddr: address},
 	)
 	if err != nil {
+		ErrorGauge.WithLabelValues("tendermint_validator_jailed").Inc()
 		log.Print(err)
-		return
+		return err
 	}
 
+	moniker := collector.monikerOf(validator.Validator)
+
 	// Jail handle
 	var jailed float64
 	if validator.Validator.Jailed {
@@ -27,25 +51,24 @@ func (collector *CosmosSDKCollector) CollectValidatorStat() {
 	} else {
 		jailed = 0
 	}
-	ValidatorJailStatusGauge.WithLabelValues(collector.valAddress, collector.chainID).Set(jailed)
+	withLabelValues(ctx, gauges.jailed, address, collector.chainID, moniker).Set(jailed)
 
 	// Commission rate handle
 	if rate, err := strconv.ParseFloat(validator.Validator.Commission.CommissionRates.Rate.String(), 64); err != nil {
+		ErrorGauge.WithLabelValues("tendermint_validator_commission_rate").Inc()
+		log.Print(err)
 	} else {
-		ValidatorCommissionRateGauge.WithLabelValues(collector.valAddress, collector.chainID).Set(rate)
+		withLabelValues(ctx, gauges.commissionRate, address, collector.chainID, moniker).Set(rate)
 	}
 
-	// Voting power handle
-
-	if value, err := strconv.ParseFloat(validator.Validator.DelegatorShares.String(), 64); err != nil {
-	} else {
-		baseDenom, found := collector.denomMetadata[collector.defaultBondDenom]
-		if !found {
-			log.Print("No denom infos")
-			return
-		}
-		fromBaseToDisplay := value / math.Pow10(int(baseDenom.Exponent))
-		ValidatorVotingPowerGauge.WithLabelValues(collector.valAddress, collector.chainID, baseDenom.Display).Set(fromBaseToDisplay)
+	// Voting power handle, from the tokens as the delegator shares are not
+	// reduced by slashing
+	baseDenom, found := collector.denomMetadata[collector.defaultBondDenom]
+	if !found {
+		ErrorGauge.WithLabelValues("tendermint_validator_voting_power_total").Inc()
+		log.Print("No denom infos")
+		return &types.DenomNotFound{}
 	}
-
+	withLabelValues(ctx, gauges.votingPower, address, collector.chainID, baseDenom.Display, moniker).Set(toDisplay(validator.Validator.Tokens, baseDenom.Exponent))
+	return nil
 }
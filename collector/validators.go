@@ -0,0 +1,79 @@
+package collector
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	sdkmath "cosmossdk.io/math"
+	querytypes "github.com/cosmos/cosmos-sdk/types/query"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// queryValidators pages through all the validators
+func (collector *CosmosSDKCollector) queryValidators(ctx context.Context) ([]stakingtypes.Validator, error) {
+	stakingClient := stakingtypes.NewQueryClient(collector.grpcConn)
+
+	var validators []stakingtypes.Validator
+	var nextKey []byte
+	for {
+		queryCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+		res, err := stakingClient.Validators(
+			queryCtx,
+			&stakingtypes.QueryValidatorsRequest{
+				Pagination: &querytypes.PageRequest{
+					Key:   nextKey,
+					Limit: 1000,
+				},
+			},
+		)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+
+		validators = append(validators, res.Validators...)
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return validators, nil
+		}
+		nextKey = res.Pagination.NextKey
+	}
+}
+
+// toDisplay converts an amount of the base denom to the display denom
+func toDisplay(amount sdkmath.Int, exponent uint32) float64 {
+	value, err := strconv.ParseFloat(amount.String(), 64)
+	if err != nil {
+		return 0
+	}
+	return value / math.Pow10(int(exponent))
+}
+
+// decToFloat converts a decimal, 0 when it does not fit in a float64
+func decToFloat(amount sdkmath.LegacyDec) float64 {
+	value, err := strconv.ParseFloat(amount.String(), 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// decCoinToDisplay converts a decimal amount of denom to its display denom,
+// false when denom has no metadata, e.g. for most IBC vouchers
+func (collector *CosmosSDKCollector) decCoinToDisplay(denom string, amount sdkmath.LegacyDec) (string, float64, bool) {
+	baseDenom, found := collector.denomMetadata[denom]
+	if !found {
+		return "", 0, false
+	}
+	return baseDenom.Display, decToFloat(amount) / math.Pow10(int(baseDenom.Exponent)), true
+}
+
+// coinToDisplay converts an amount of denom to its display denom, false when
+// denom has no metadata
+func (collector *CosmosSDKCollector) coinToDisplay(denom string, amount sdkmath.Int) (string, float64, bool) {
+	baseDenom, found := collector.denomMetadata[denom]
+	if !found {
+		return "", 0, false
+	}
+	return baseDenom.Display, toDisplay(amount, baseDenom.Exponent), true
+}
@@ -8,43 +8,34 @@ import (
 	"strconv"
 
 	sdkmath "cosmossdk.io/math"
-	querytypes "github.com/cosmos/cosmos-sdk/types/query"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	types "github.com/forbole/cosmos-exporter/types"
 )
 
-func (collector *CosmosSDKCollector) CollectValidatorsStat() {
+func (collector *CosmosSDKCollector) CollectValidatorsStat(ctx context.Context) error {
 	if collector.sdkVersion == SDKVersionLegacy {
-		collector.collectValidatorsStatLegacy()
-	} else {
-		collector.collectValidatorsStatCurrent()
+		return collector.collectValidatorsStatLegacy(ctx)
 	}
+	return collector.collectValidatorsStatCurrent(ctx)
 }
 
 // Implementation for both SDK versions with version-specific conversions
-func (collector *CosmosSDKCollector) collectValidatorsStatLegacy() {
-	stakingClient := stakingtypes.NewQueryClient(collector.grpcConn)
-	validatorsResponse, err := stakingClient.Validators(
-		context.Background(),
-		&stakingtypes.QueryValidatorsRequest{
-			Pagination: &querytypes.PageRequest{
-				Limit: 1000,
-			},
-		},
-	)
+func (collector *CosmosSDKCollector) collectValidatorsStatLegacy(ctx context.Context) error {
+	valAddress := collector.validatorAddress()
+	validators, err := collector.allValidators(ctx)
 	if err != nil {
 		ErrorGauge.WithLabelValues("tendermint_voting_power_total").Inc()
 		log.Print(err)
-		return
+		return err
 	}
+	collector.exportDecentralization(validators)
 
 	var validatorRanking int
 	var bondedTokensTotalStr, notBondedTokensTotalStr string
 
-	validators := validatorsResponse.Validators
-
-	// Sort to get validator ranking.
-	sort.Slice(validators, func(i, j int) bool {
-		return validators[i].DelegatorShares.GT(validators[j].DelegatorShares)
+	// Rank by tokens, which give the voting power, like the bonded rank
+	sort.SliceStable(validators, func(i, j int) bool {
+		return validators[i].Tokens.GT(validators[j].Tokens)
 	})
 
 	// For legacy SDK, use safer string approach to avoid type mismatches
@@ -52,11 +43,6 @@ func (collector *CosmosSDKCollector) collectValidatorsStatLegacy() {
 	notBondedTokensTotal := int64(0)
 
 	for index, validator := range validators {
-		if err != nil {
-			log.Print(err)
-			return
-		}
-
 		// Accumulate tokens as string to handle large amounts
 		switch validator.GetStatus() {
 		case stakingtypes.Bonded:
@@ -69,10 +55,12 @@ func (collector *CosmosSDKCollector) collectValidatorsStatLegacy() {
 			notBondedTokensTotalStr = addTokenAmountsAsStrings(notBondedTokensTotalStr, tokenStr)
 
 		default:
-			panic("invalid validator status")
+			// An unknown status is not counted rather than failing the collection
+			ErrorGauge.WithLabelValues("tendermint_voting_power_total").Inc()
+			log.Printf("Invalid status %s of validator %s", validator.GetStatus(), validator.OperatorAddress)
 		}
 
-		if validator.OperatorAddress == collector.valAddress {
+		if validator.OperatorAddress == valAddress {
 			validatorRanking = index + 1
 		}
 	}
@@ -93,8 +81,9 @@ func (collector *CosmosSDKCollector) collectValidatorsStatLegacy() {
 
 	baseDenom, found := collector.denomMetadata[collector.defaultBondDenom]
 	if !found {
+		ErrorGauge.WithLabelValues("tendermint_voting_power_total").Inc()
 		log.Print("No denom infos")
-		return
+		return &types.DenomNotFound{}
 	}
 
 	bondedTokensTodisplay := bondedTokensToFloat / math.Pow10(int(baseDenom.Exponent))
@@ -102,43 +91,31 @@ func (collector *CosmosSDKCollector) collectValidatorsStatLegacy() {
 
 	BondedTokenGauge.WithLabelValues(collector.chainID).Set(bondedTokensTodisplay)
 	NotBondedTokenGauge.WithLabelValues(collector.chainID).Set(notBondedTokensTodisplay)
-	ValidatorVotingPowerRanking.WithLabelValues(collector.valAddress, collector.chainID).Set(float64(validatorRanking))
+	withLabelValues(ctx, ValidatorVotingPowerRanking, valAddress, collector.chainID, collector.validatorMoniker()).Set(float64(validatorRanking))
+	return nil
 }
 
 // Implementation for v0.50.x chains using updated math types
-func (collector *CosmosSDKCollector) collectValidatorsStatCurrent() {
-	stakingClient := stakingtypes.NewQueryClient(collector.grpcConn)
-	validatorsResponse, err := stakingClient.Validators(
-		context.Background(),
-		&stakingtypes.QueryValidatorsRequest{
-			Pagination: &querytypes.PageRequest{
-				Limit: 1000,
-			},
-		},
-	)
+func (collector *CosmosSDKCollector) collectValidatorsStatCurrent(ctx context.Context) error {
+	valAddress := collector.validatorAddress()
+	validators, err := collector.allValidators(ctx)
 	if err != nil {
 		ErrorGauge.WithLabelValues("tendermint_voting_power_total").Inc()
 		log.Print(err)
-		return
+		return err
 	}
+	collector.exportDecentralization(validators)
 
 	var validatorRanking int
 	bondedTokens := sdkmath.NewInt(0)
 	notBondedTokens := sdkmath.NewInt(0)
 
-	validators := validatorsResponse.Validators
-
-	// Sort to get validator ranking.
-	sort.Slice(validators, func(i, j int) bool {
-		return validators[i].DelegatorShares.GT(validators[j].DelegatorShares)
+	// Rank by tokens, which give the voting power, like the bonded rank
+	sort.SliceStable(validators, func(i, j int) bool {
+		return validators[i].Tokens.GT(validators[j].Tokens)
 	})
 
 	for index, validator := range validators {
-		if err != nil {
-			log.Print(err)
-			return
-		}
-
 		switch validator.GetStatus() {
 		case stakingtypes.Bonded:
 			bondedTokens = bondedTokens.Add(validator.GetTokens())
@@ -147,10 +124,12 @@ func (collector *CosmosSDKCollector) collectValidatorsStatCurrent() {
 			notBondedTokens = notBondedTokens.Add(validator.GetTokens())
 
 		default:
-			panic("invalid validator status")
+			// An unknown status is not counted rather than failing the collection
+			ErrorGauge.WithLabelValues("tendermint_voting_power_total").Inc()
+			log.Printf("Invalid status %s of validator %s", validator.GetStatus(), validator.OperatorAddress)
 		}
 
-		if validator.OperatorAddress == collector.valAddress {
+		if validator.OperatorAddress == valAddress {
 			validatorRanking = index + 1
 		}
 	}
@@ -159,20 +138,21 @@ func (collector *CosmosSDKCollector) collectValidatorsStatCurrent() {
 	if err != nil {
 		ErrorGauge.WithLabelValues("tendermint_voting_power_total").Inc()
 		log.Print(err)
-		return
+		return err
 	}
 
 	notBondedTokensToFloat, err := strconv.ParseFloat(notBondedTokens.String(), 64)
 	if err != nil {
 		ErrorGauge.WithLabelValues("tendermint_voting_power_total").Inc()
 		log.Print(err)
-		return
+		return err
 	}
 
 	baseDenom, found := collector.denomMetadata[collector.defaultBondDenom]
 	if !found {
+		ErrorGauge.WithLabelValues("tendermint_voting_power_total").Inc()
 		log.Print("No denom infos")
-		return
+		return &types.DenomNotFound{}
 	}
 
 	bondedTokensTodisplay := bondedTokensToFloat / math.Pow10(int(baseDenom.Exponent))
@@ -180,7 +160,8 @@ func (collector *CosmosSDKCollector) collectValidatorsStatCurrent() {
 
 	BondedTokenGauge.WithLabelValues(collector.chainID).Set(bondedTokensTodisplay)
 	NotBondedTokenGauge.WithLabelValues(collector.chainID).Set(notBondedTokensTodisplay)
-	ValidatorVotingPowerRanking.WithLabelValues(collector.valAddress, collector.chainID).Set(float64(validatorRanking))
+	withLabelValues(ctx, ValidatorVotingPowerRanking, valAddress, collector.chainID, collector.validatorMoniker()).Set(float64(validatorRanking))
+	return nil
 }
 
 // Helper function to add two token amounts represented as strings
@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"context"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+func TestCollectValidatorsStatRanking(t *testing.T) {
+	// val1 was slashed, it has more delegator shares but fewer tokens than val2
+	slashed := testValidator("val1", stakingtypes.Bonded, 300_000_000)
+	slashed.DelegatorShares = sdkmath.LegacyNewDec(600_000_000)
+	validators := []stakingtypes.Validator{
+		slashed,
+		testValidator("val2", stakingtypes.Bonded, 400_000_000),
+		testValidator("val3", stakingtypes.Unbonded, 100_000_000),
+	}
+
+	tests := []struct {
+		sdkVersion SDKVersion
+		valAddress string
+		wantRank   float64
+	}{
+		{SDKVersionCurrent, "val1", 2},
+		{SDKVersionCurrent, "val2", 1},
+		{SDKVersionCurrent, "val3", 3},
+		{SDKVersionLegacy, "val1", 2},
+		{SDKVersionLegacy, "val2", 1},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.sdkVersion)+" "+tt.valAddress, func(t *testing.T) {
+			conn := newTestConn(t, func(server *grpc.Server) {
+				stakingtypes.RegisterQueryServer(server, &fakeStaking{validators: validators})
+			})
+			collector := newTestCollector(conn, tt.valAddress)
+			collector.sdkVersion = tt.sdkVersion
+			defer DeleteChainSeries(collector.chainID)
+
+			if err := collector.CollectValidatorsStat(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			if got, _ := gaugeValue(t, ValidatorVotingPowerRanking, prometheus.Labels{"validator_address": tt.valAddress}); got != tt.wantRank {
+				t.Errorf("ranking = %v, want %v", got, tt.wantRank)
+			}
+			if got, _ := gaugeValue(t, BondedTokenGauge, prometheus.Labels{"chain_id": "test-1"}); got != 700 {
+				t.Errorf("bonded tokens = %v, want 700", got)
+			}
+			if got, _ := gaugeValue(t, NotBondedTokenGauge, prometheus.Labels{"chain_id": "test-1"}); got != 100 {
+				t.Errorf("not bonded tokens = %v, want 100", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package collector
+
+import (
+	"context"
+	"log"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	querytypes "github.com/cosmos/cosmos-sdk/types/query"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingexported "github.com/cosmos/cosmos-sdk/x/auth/vesting/exported"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CollectVesting exports the vesting schedule and spendable balances of the
+// monitored addresses which are vesting accounts
+func (collector *CosmosSDKCollector) CollectVesting(ctx context.Context) error {
+	statusCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	// Vesting is computed at the time of the latest block like the chain does
+	status, err := nodeStatus(statusCtx, collector.rpcClient)
+	if err != nil {
+		ErrorGauge.WithLabelValues("cosmos_auth_vesting_locked_tokens").Inc()
+		log.Print(err)
+		return err
+	}
+	blockTime := status.SyncInfo.LatestBlockTime
+
+	return collector.forEachAddress(ctx, func(ctx context.Context, address string) error {
+		return collector.collectVesting(ctx, address, blockTime)
+	})
+}
+
+func (collector *CosmosSDKCollector) collectVesting(ctx context.Context, address string, blockTime time.Time) error {
+	accountCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	authClient := authtypes.NewQueryClient(collector.grpcConn)
+	accountRes, err := authClient.Account(accountCtx, &authtypes.QueryAccountRequest{Address: address})
+	// Addresses which never received tokens have no account
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	if err != nil {
+		ErrorGauge.WithLabelValues("cosmos_auth_vesting_locked_tokens").Inc()
+		log.Print(err)
+		return err
+	}
+	account, err := decodeVestingAccount(accountRes.Account)
+	if err != nil {
+		ErrorGauge.WithLabelValues("cosmos_auth_vesting_locked_tokens").Inc()
+		log.Printf("Error decoding the vesting account %s: %v", address, err)
+		return err
+	}
+	if account == nil {
+		return nil
+	}
+
+	labels := collector.addressLabels(address)
+	vestingAmounts := []struct {
+		gauge *prometheus.GaugeVec
+		coins sdk.Coins
+	}{
+		{VestingOriginalGauge, account.GetOriginalVesting()},
+		{VestingVestedGauge, account.GetVestedCoins(blockTime)},
+		{VestingLockedGauge, account.GetVestingCoins(blockTime)},
+		{VestingDelegatedGauge, account.GetDelegatedVesting()},
+	}
+	// Every denom of the schedule is exported, as coins drop zero amounts
+	for _, original := range account.GetOriginalVesting() {
+		if _, found := collector.denomMetadata[original.Denom]; !found {
+			ErrorGauge.WithLabelValues("cosmos_auth_vesting_locked_tokens").Inc()
+			log.Printf("No denom infos for %s", original.Denom)
+			continue
+		}
+		for _, vesting := range vestingAmounts {
+			denom, amount, _ := collector.coinToDisplay(original.Denom, vesting.coins.AmountOf(original.Denom))
+			withLabelValues(ctx, vesting.gauge, collector.chainID, address, denom, labels.Alias, labels.Owner, labels.Team).Set(amount)
+		}
+	}
+	withLabelValues(ctx, VestingNextUnlockGauge, collector.chainID, address, labels.Alias, labels.Owner, labels.Team).Set(float64(nextUnlock(account, blockTime)))
+
+	spendableCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	bankClient := banktypes.NewQueryClient(collector.grpcConn)
+	spendableRes, err := bankClient.SpendableBalances(
+		spendableCtx,
+		&banktypes.QuerySpendableBalancesRequest{
+			Address: address,
+			Pagination: &querytypes.PageRequest{
+				Limit: 1000,
+			},
+		},
+	)
+	if err != nil {
+		ErrorGauge.WithLabelValues("cosmos_bank_spendable_balance_tokens").Inc()
+		log.Print(err)
+		return err
+	}
+	for _, balance := range spendableRes.Balances {
+		denom, amount, found := collector.coinToDisplay(balance.Denom, balance.Amount)
+		if !found {
+			ErrorGauge.WithLabelValues("cosmos_bank_spendable_balance_tokens").Inc()
+			log.Printf("No denom infos for %s", balance.Denom)
+			continue
+		}
+		withLabelValues(ctx, SpendableBalanceGauge, collector.chainID, address, denom, labels.Alias, labels.Owner, labels.Team).Set(amount)
+	}
+	return nil
+}
+
+// decodeVestingAccount decodes the vesting account types of the SDK, nil for
+// any other account type, including the custom ones of the chain. The
+// concrete type is decoded on its own so its public key, whose type may be
+// unknown, is not unpacked.
+func decodeVestingAccount(packed *codectypes.Any) (vestingexported.VestingAccount, error) {
+	var account interface {
+		vestingexported.VestingAccount
+		Unmarshal([]byte) error
+	}
+	switch packed.GetTypeUrl() {
+	case "/cosmos.vesting.v1beta1.ContinuousVestingAccount":
+		account = &vestingtypes.ContinuousVestingAccount{}
+	case "/cosmos.vesting.v1beta1.DelayedVestingAccount":
+		account = &vestingtypes.DelayedVestingAccount{}
+	case "/cosmos.vesting.v1beta1.PeriodicVestingAccount":
+		account = &vestingtypes.PeriodicVestingAccount{}
+	default:
+		return nil, nil
+	}
+
+	if err := account.Unmarshal(packed.GetValue()); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// nextUnlock returns the unix time at which tokens of account vest next,
+// blockTime while vesting continuously and 0 once fully vested
+func nextUnlock(account vestingexported.VestingAccount, blockTime time.Time) int64 {
+	now := blockTime.Unix()
+	switch account := account.(type) {
+	case *vestingtypes.ContinuousVestingAccount:
+		if now < account.StartTime {
+			return account.StartTime
+		}
+		if now < account.EndTime {
+			return now
+		}
+	case *vestingtypes.DelayedVestingAccount:
+		if now < account.EndTime {
+			return account.EndTime
+		}
+	case *vestingtypes.PeriodicVestingAccount:
+		unlock := account.StartTime
+		for _, period := range account.VestingPeriods {
+			unlock += period.Length
+			if unlock > now {
+				return unlock
+			}
+		}
+	}
+	return 0
+}
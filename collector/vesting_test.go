@@ -0,0 +1,97 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingexported "github.com/cosmos/cosmos-sdk/x/auth/vesting/exported"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+)
+
+func TestNextUnlock(t *testing.T) {
+	continuous := &vestingtypes.ContinuousVestingAccount{
+		BaseVestingAccount: &vestingtypes.BaseVestingAccount{EndTime: 2000},
+		StartTime:          1000,
+	}
+	delayed := &vestingtypes.DelayedVestingAccount{
+		BaseVestingAccount: &vestingtypes.BaseVestingAccount{EndTime: 2000},
+	}
+	periodic := &vestingtypes.PeriodicVestingAccount{
+		BaseVestingAccount: &vestingtypes.BaseVestingAccount{EndTime: 1300},
+		StartTime:          1000,
+		VestingPeriods: vestingtypes.Periods{
+			{Length: 100},
+			{Length: 200},
+		},
+	}
+	tests := []struct {
+		name    string
+		account vestingexported.VestingAccount
+		now     int64
+		want    int64
+	}{
+		{"continuous before the start", continuous, 500, 1000},
+		{"continuous while vesting", continuous, 1500, 1500},
+		{"continuous fully vested", continuous, 2000, 0},
+		{"delayed before the end", delayed, 1500, 2000},
+		{"delayed fully vested", delayed, 2000, 0},
+		{"periodic before the start", periodic, 500, 1100},
+		{"periodic at the first unlock", periodic, 1100, 1300},
+		{"periodic between unlocks", periodic, 1200, 1300},
+		{"periodic fully vested", periodic, 1300, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextUnlock(tt.account, time.Unix(tt.now, 0)); got != tt.want {
+				t.Errorf("nextUnlock at %d = %d, want %d", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeVestingAccount(t *testing.T) {
+	base := &authtypes.BaseAccount{
+		Address: "cosmos1test",
+		// A public key type the exporter does not register
+		PubKey: &codectypes.Any{TypeUrl: "/chain.crypto.v1.PubKey", Value: []byte{0x0a, 0x01, 0x01}},
+	}
+	vestingAccount := &vestingtypes.DelayedVestingAccount{
+		BaseVestingAccount: &vestingtypes.BaseVestingAccount{BaseAccount: base, EndTime: 2000},
+	}
+	vestingValue, err := vestingAccount.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseValue, err := base.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		packed      *codectypes.Any
+		wantAccount bool
+		wantErr     bool
+	}{
+		{"vesting account", &codectypes.Any{TypeUrl: "/cosmos.vesting.v1beta1.DelayedVestingAccount", Value: vestingValue}, true, false},
+		{"base account", &codectypes.Any{TypeUrl: "/cosmos.auth.v1beta1.BaseAccount", Value: baseValue}, false, false},
+		{"custom account", &codectypes.Any{TypeUrl: "/chain.auth.v1.Account", Value: []byte{0xff}}, false, false},
+		{"invalid vesting account", &codectypes.Any{TypeUrl: "/cosmos.vesting.v1beta1.ContinuousVestingAccount", Value: []byte{0xff}}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, err := decodeVestingAccount(tt.packed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeVestingAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (account != nil) != tt.wantAccount {
+				t.Fatalf("decodeVestingAccount() = %v, want an account %v", account, tt.wantAccount)
+			}
+			if account != nil && account.GetEndTime() != 2000 {
+				t.Errorf("end time = %d, want 2000", account.GetEndTime())
+			}
+		})
+	}
+}
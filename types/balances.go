@@ -0,0 +1,30 @@
+package types
+
+import "time"
+
+// Balances configures the low balance alerting of delegator addresses
+type Balances struct {
+	// Window is the balance history used to estimate the spend rate
+	Window time.Duration `mapstructure:"window"`
+	// Thresholds are the minimum balances by address and display denom
+	Thresholds map[string]map[string]float64 `mapstructure:"thresholds"`
+}
+
+func NewBalances(window time.Duration, thresholds map[string]map[string]float64) Balances {
+	return Balances{
+		Window:     window,
+		Thresholds: thresholds,
+	}
+}
+
+func DefaultBalancesConfig() Balances {
+	return NewBalances(time.Hour, nil)
+}
+
+// WithDefaults replaces the unset window with the default one
+func (b Balances) WithDefaults() Balances {
+	if b.Window <= 0 {
+		b.Window = DefaultBalancesConfig().Window
+	}
+	return b
+}
@@ -0,0 +1,29 @@
+package types
+
+type Concurrency struct {
+	Collectors  int `mapstructure:"collectors"`
+	MaxRequests int `mapstructure:"max_requests"`
+}
+
+func NewConcurrency(collectors int, maxRequests int) Concurrency {
+	return Concurrency{
+		Collectors:  collectors,
+		MaxRequests: maxRequests,
+	}
+}
+
+func DefaultConcurrencyConfig() Concurrency {
+	return NewConcurrency(4, 10)
+}
+
+// WithDefaults replaces the unset limits with the default ones
+func (c Concurrency) WithDefaults() Concurrency {
+	defaults := DefaultConcurrencyConfig()
+	if c.Collectors <= 0 {
+		c.Collectors = defaults.Collectors
+	}
+	if c.MaxRequests <= 0 {
+		c.MaxRequests = defaults.MaxRequests
+	}
+	return c
+}
@@ -6,17 +6,31 @@ import (
 
 // Config defines all necessary parameters
 type Config struct {
-	DelegatorAddresses []string            `mapstructure:"delegator_addresses"`
-	ValidatorAddress   string              `mapstructure:"validator_address"`
-	Port               string              `mapstructure:"port"`
-	DenomMetadata      types.DenomMetadata `mapstructure:"denom_metadata"`
-	Node               types.Node          `mapstructure:"node"`
+	DelegatorAddresses []string             `mapstructure:"delegator_addresses"`
+	ValidatorAddress   string               `mapstructure:"validator_address"`
+	Port               string               `mapstructure:"port"`
+	DenomMetadata      types.DenomMetadata  `mapstructure:"denom_metadata"`
+	Node               types.Node           `mapstructure:"node"`
+	Concurrency        types.Concurrency    `mapstructure:"concurrency"`
+	Retry              types.Retry          `mapstructure:"retry"`
+	CircuitBreaker     types.CircuitBreaker `mapstructure:"circuit_breaker"`
+	Health             types.Health         `mapstructure:"health"`
+	Labels             types.Labels         `mapstructure:"labels"`
+	Balances           types.Balances       `mapstructure:"balances"`
+	Metrics            types.Metrics        `mapstructure:"metrics"`
+	ValidatorSet       types.ValidatorSet   `mapstructure:"validator_set"`
+	Validator          types.Validator      `mapstructure:"validator"`
+	Delegators         types.Delegators     `mapstructure:"delegators"`
+	WebConfigFile      string               `mapstructure:"web_config_file"`
 }
 
 // NewConfig builds a new Config instance
 func NewConfig(
 	delegatorAddresses []string, validatorAddress string, port string,
-	nodeCfg types.Node, denomMetadataCfg types.DenomMetadata,
+	nodeCfg types.Node, denomMetadataCfg types.DenomMetadata, concurrencyCfg types.Concurrency,
+	retryCfg types.Retry, circuitBreakerCfg types.CircuitBreaker, healthCfg types.Health,
+	labelsCfg types.Labels, balancesCfg types.Balances, metricsCfg types.Metrics, validatorSetCfg types.ValidatorSet,
+	validatorCfg types.Validator, delegatorsCfg types.Delegators, webConfigFile string,
 ) Config {
 	return Config{
 		DelegatorAddresses: delegatorAddresses,
@@ -24,5 +38,16 @@ func NewConfig(
 		Port:               port,
 		Node:               nodeCfg,
 		DenomMetadata:      denomMetadataCfg,
+		Concurrency:        concurrencyCfg,
+		Retry:              retryCfg,
+		CircuitBreaker:     circuitBreakerCfg,
+		Health:             healthCfg,
+		Labels:             labelsCfg,
+		Balances:           balancesCfg,
+		Metrics:            metricsCfg,
+		ValidatorSet:       validatorSetCfg,
+		Validator:          validatorCfg,
+		Delegators:         delegatorsCfg,
+		WebConfigFile:      webConfigFile,
 	}
 }
@@ -0,0 +1,25 @@
+package types
+
+// Delegators configures the metrics of the delegators of the monitored validator
+type Delegators struct {
+	// Top is the number of largest delegators exported
+	Top int `mapstructure:"top"`
+}
+
+func NewDelegators(top int) Delegators {
+	return Delegators{
+		Top: top,
+	}
+}
+
+func DefaultDelegatorsConfig() Delegators {
+	return NewDelegators(10)
+}
+
+// WithDefaults replaces the unset values with the default ones
+func (d Delegators) WithDefaults() Delegators {
+	if d.Top <= 0 {
+		d.Top = DefaultDelegatorsConfig().Top
+	}
+	return d
+}
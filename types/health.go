@@ -0,0 +1,23 @@
+package types
+
+type Health struct {
+	StaleIntervals int `mapstructure:"stale_intervals"`
+}
+
+func NewHealth(staleIntervals int) Health {
+	return Health{
+		StaleIntervals: staleIntervals,
+	}
+}
+
+func DefaultHealthConfig() Health {
+	return NewHealth(3)
+}
+
+// WithDefaults replaces the unset values with the default ones
+func (h Health) WithDefaults() Health {
+	if h.StaleIntervals <= 0 {
+		h.StaleIntervals = DefaultHealthConfig().StaleIntervals
+	}
+	return h
+}
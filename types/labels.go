@@ -0,0 +1,24 @@
+package types
+
+// AddressLabels are the extra labels added to the metrics of a delegator address
+type AddressLabels struct {
+	Alias string `mapstructure:"alias"`
+	Owner string `mapstructure:"owner"`
+	Team  string `mapstructure:"team"`
+}
+
+type Labels struct {
+	ValidatorMoniker bool                     `mapstructure:"validator_moniker"`
+	Addresses        map[string]AddressLabels `mapstructure:"addresses"`
+}
+
+func NewLabels(validatorMoniker bool, addresses map[string]AddressLabels) Labels {
+	return Labels{
+		ValidatorMoniker: validatorMoniker,
+		Addresses:        addresses,
+	}
+}
+
+func DefaultLabelsConfig() Labels {
+	return NewLabels(false, nil)
+}
@@ -0,0 +1,23 @@
+package types
+
+// Metrics configures the names of the exported metrics and the labels added
+// to them or the names filtered out
+type Metrics struct {
+	Naming      string            `mapstructure:"naming"`
+	ConstLabels map[string]string `mapstructure:"const_labels"`
+	Allow       []string          `mapstructure:"allow"`
+	Deny        []string          `mapstructure:"deny"`
+}
+
+func NewMetrics(naming string, constLabels map[string]string, allow []string, deny []string) Metrics {
+	return Metrics{
+		Naming:      naming,
+		ConstLabels: constLabels,
+		Allow:       allow,
+		Deny:        deny,
+	}
+}
+
+func DefaultMetricsConfig() Metrics {
+	return NewMetrics("legacy", nil, nil, nil)
+}
@@ -1,9 +1,36 @@
 package types
 
 type Node struct {
-	RPC      string `mapstructure:"rpc"`
-	GRPC     string `mapstructure:"grpc"`
-	IsSecure bool   `mapstructure:"secure"`
+	RPC      string   `mapstructure:"rpc"`
+	GRPC     string   `mapstructure:"grpc"`
+	IsSecure bool     `mapstructure:"secure"`
+	TLS      NodeTLS  `mapstructure:"tls"`
+	Auth     NodeAuth `mapstructure:"auth"`
+}
+
+// NodeTLS customizes the TLS connection to the node, it is only used
+// for the gRPC endpoint when secure is true and for https RPC endpoints
+type NodeTLS struct {
+	CAFile             string `mapstructure:"ca_file"`
+	CertFile           string `mapstructure:"cert_file"`
+	KeyFile            string `mapstructure:"key_file"`
+	ServerName         string `mapstructure:"server_name"`
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
+}
+
+// NodeAuth holds the credentials sent with every gRPC query and RPC call.
+// Secrets can be set inline, or read from a file with the *_file fields.
+type NodeAuth struct {
+	Headers      map[string]string `mapstructure:"headers"`
+	HeaderFiles  map[string]string `mapstructure:"header_files"`
+	BearerToken  string            `mapstructure:"bearer_token"`
+	TokenFile    string            `mapstructure:"bearer_token_file"`
+	Username     string            `mapstructure:"username"`
+	Password     string            `mapstructure:"password"`
+	PasswordFile string            `mapstructure:"password_file"`
+	// Insecure allows sending the credentials to plaintext endpoints,
+	// e.g. behind a TLS terminating proxy
+	Insecure bool `mapstructure:"insecure"`
 }
 
 func NewNode(
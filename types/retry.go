@@ -0,0 +1,82 @@
+package types
+
+import "time"
+
+type Retry struct {
+	MaxAttempts    int           `mapstructure:"max_attempts"`
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+	Multiplier     float64       `mapstructure:"multiplier"`
+	RetryableCodes []string      `mapstructure:"retryable_codes"`
+}
+
+func NewRetry(
+	maxAttempts int, initialBackoff time.Duration, maxBackoff time.Duration, multiplier float64, retryableCodes []string,
+) Retry {
+	return Retry{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: initialBackoff,
+		MaxBackoff:     maxBackoff,
+		Multiplier:     multiplier,
+		RetryableCodes: retryableCodes,
+	}
+}
+
+func DefaultRetryConfig() Retry {
+	return NewRetry(3, 250*time.Millisecond, 2*time.Second, 2, []string{"Unavailable", "ResourceExhausted", "Aborted"})
+}
+
+// WithDefaults replaces the unset fields with the default ones
+func (r Retry) WithDefaults() Retry {
+	defaults := DefaultRetryConfig()
+	if r.MaxAttempts <= 0 {
+		r.MaxAttempts = defaults.MaxAttempts
+	}
+	if r.InitialBackoff <= 0 {
+		r.InitialBackoff = defaults.InitialBackoff
+	}
+	if r.MaxBackoff <= 0 {
+		r.MaxBackoff = defaults.MaxBackoff
+	}
+	if r.Multiplier < 1 {
+		r.Multiplier = defaults.Multiplier
+	}
+	if len(r.RetryableCodes) == 0 {
+		r.RetryableCodes = defaults.RetryableCodes
+	}
+	return r
+}
+
+type CircuitBreaker struct {
+	FailureThreshold int           `mapstructure:"failure_threshold"`
+	OpenTimeout      time.Duration `mapstructure:"open_timeout"`
+}
+
+func NewCircuitBreaker(failureThreshold int, openTimeout time.Duration) CircuitBreaker {
+	return CircuitBreaker{
+		FailureThreshold: failureThreshold,
+		OpenTimeout:      openTimeout,
+	}
+}
+
+func DefaultCircuitBreakerConfig() CircuitBreaker {
+	return NewCircuitBreaker(5, 30*time.Second)
+}
+
+// WithDefaults replaces the unset fields with the default ones,
+// a negative FailureThreshold disables the circuit breaker
+func (c CircuitBreaker) WithDefaults() CircuitBreaker {
+	defaults := DefaultCircuitBreakerConfig()
+	if c.FailureThreshold == 0 {
+		c.FailureThreshold = defaults.FailureThreshold
+	}
+	if c.OpenTimeout <= 0 {
+		c.OpenTimeout = defaults.OpenTimeout
+	}
+	return c
+}
+
+// IsEnabled returns false when the circuit breaker has been disabled
+func (c CircuitBreaker) IsEnabled() bool {
+	return c.FailureThreshold >= 0
+}
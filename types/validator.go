@@ -0,0 +1,41 @@
+package types
+
+// Validator configures the metrics of the monitored validator
+type Validator struct {
+	// PowerReduction is the number of tokens per unit of consensus power,
+	// the power mismatch is not checked when 0
+	PowerReduction int64 `mapstructure:"power_reduction"`
+	// SelfDelegationMargin is the share above the minimum self-delegation
+	// from which the validator is flagged as close to it
+	SelfDelegationMargin float64 `mapstructure:"self_delegation_margin"`
+	// SlashWindow is the number of latest blocks whose slashes are exported,
+	// the whole history when 0
+	SlashWindow int64 `mapstructure:"slash_window"`
+}
+
+func NewValidator(powerReduction int64, selfDelegationMargin float64, slashWindow int64) Validator {
+	return Validator{
+		PowerReduction:       powerReduction,
+		SelfDelegationMargin: selfDelegationMargin,
+		SlashWindow:          slashWindow,
+	}
+}
+
+func DefaultValidatorConfig() Validator {
+	return NewValidator(0, 0.1, 0)
+}
+
+// WithDefaults replaces the unset values with the default ones
+func (v Validator) WithDefaults() Validator {
+	defaults := DefaultValidatorConfig()
+	if v.PowerReduction < 0 {
+		v.PowerReduction = defaults.PowerReduction
+	}
+	if v.SelfDelegationMargin <= 0 {
+		v.SelfDelegationMargin = defaults.SelfDelegationMargin
+	}
+	if v.SlashWindow < 0 {
+		v.SlashWindow = defaults.SlashWindow
+	}
+	return v
+}
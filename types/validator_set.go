@@ -0,0 +1,29 @@
+package types
+
+type ValidatorSet struct {
+	Enabled       bool     `mapstructure:"enabled"`
+	Top           int      `mapstructure:"top"`
+	Allowlist     []string `mapstructure:"allowlist"`
+	MaxValidators int      `mapstructure:"max_validators"`
+}
+
+func NewValidatorSet(enabled bool, top int, allowlist []string, maxValidators int) ValidatorSet {
+	return ValidatorSet{
+		Enabled:       enabled,
+		Top:           top,
+		Allowlist:     allowlist,
+		MaxValidators: maxValidators,
+	}
+}
+
+func DefaultValidatorSetConfig() ValidatorSet {
+	return NewValidatorSet(false, 0, nil, 200)
+}
+
+// WithDefaults replaces the unset cap with the default one
+func (v ValidatorSet) WithDefaults() ValidatorSet {
+	if v.MaxValidators <= 0 {
+		v.MaxValidators = DefaultValidatorSetConfig().MaxValidators
+	}
+	return v
+}